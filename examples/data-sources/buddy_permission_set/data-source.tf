@@ -0,0 +1,3 @@
+data "buddy_permission_set" "developer" {
+  name = "Developer"
+}
@@ -0,0 +1,9 @@
+data "buddy_project" "self" {
+  name = "my-project"
+}
+
+resource "buddy_project_variable" "self" {
+  project = data.buddy_project.self.name
+  key     = "ENVIRONMENT"
+  value   = "staging"
+}
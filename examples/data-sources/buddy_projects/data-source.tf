@@ -0,0 +1,12 @@
+data "buddy_projects" "backend" {
+  status     = "ACTIVE"
+  name_regex = "^backend-"
+}
+
+resource "buddy_project_variable" "environment" {
+  for_each = toset(data.buddy_projects.backend.projects[*].name)
+
+  project = each.value
+  key     = "ENVIRONMENT"
+  value   = "staging"
+}
@@ -1,3 +1,7 @@
-data "buddy_workspace_member" {
+data "buddy_workspace_member" "by_email" {
   email = "example@example.com"
-}
\ No newline at end of file
+}
+
+data "buddy_workspace_member" "by_id" {
+  id = "12345"
+}
@@ -0,0 +1,11 @@
+data "buddy_workspace_members" "developers" {
+  email_domain = "example.com"
+}
+
+resource "buddy_project_member" "developers" {
+  for_each = { for member in data.buddy_workspace_members.developers.members : member.email => member.id }
+
+  project_name      = "my-project"
+  member_id         = each.value
+  permission_set_id = 12345 # Developer
+}
@@ -1,5 +1,8 @@
 provider "buddy" {
-  buddy_url  = "https://buddy.example.com/api/workspaces/my-workspace" # Alternatively use BUDDY_URL env variable
-  token      = "dummyrandomtoken"                                      # Alternatively use BUDDY_TOKEN env variable
-  verify_ssl = true                                                    # Alternatively use BUDDY_VERIFY_SSL env variable
+  buddy_url           = "https://buddy.example.com/api/workspaces/my-workspace" # Alternatively use BUDDY_URL env variable
+  token               = "dummyrandomtoken"                                      # Alternatively use BUDDY_TOKEN env variable
+  verify_ssl          = true                                                    # Alternatively use BUDDY_VERIFY_SSL env variable
+  max_retries         = 3                                                       # Alternatively use BUDDY_MAX_RETRIES env variable
+  retry_max_wait      = 30                                                      # Alternatively use BUDDY_RETRY_MAX_WAIT env variable
+  requests_per_second = 10                                                      # Alternatively use BUDDY_REQUESTS_PER_SECOND env variable
 }
\ No newline at end of file
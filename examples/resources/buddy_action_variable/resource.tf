@@ -0,0 +1,8 @@
+resource "buddy_action_variable" "self" {
+  key         = "TEST_ACTION_VAR"
+  value       = "dummy"
+  project     = "example-project"
+  pipeline_id = 12345
+  action_id   = 67890
+  encrypted   = true
+}
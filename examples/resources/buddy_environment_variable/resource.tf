@@ -0,0 +1,7 @@
+resource "buddy_environment_variable" "self" {
+  key            = "TEST_ENVIRONMENT_VAR"
+  value          = "dummy"
+  project        = "example-project"
+  environment_id = "zK8X9gJzm"
+  encrypted      = true
+}
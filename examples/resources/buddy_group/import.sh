@@ -0,0 +1 @@
+terraform import buddy_group.developers 12345
@@ -0,0 +1,6 @@
+resource "buddy_group" "developers" {
+  name                          = "Developers"
+  description                   = "Everyone working on the backend services"
+  auto_assign_to_new_projects   = true
+  auto_assign_permission_set_id = 12345 # Developer
+}
@@ -0,0 +1 @@
+terraform import buddy_group_member.jane '12345:67890'
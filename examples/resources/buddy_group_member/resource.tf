@@ -0,0 +1,12 @@
+resource "buddy_group" "developers" {
+  name = "Developers"
+}
+
+data "buddy_workspace_member" "jane" {
+  email = "jane@example.com"
+}
+
+resource "buddy_group_member" "jane" {
+  group_id  = buddy_group.developers.id
+  member_id = data.buddy_workspace_member.jane.id
+}
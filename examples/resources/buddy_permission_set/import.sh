@@ -0,0 +1 @@
+terraform import buddy_permission_set.release_managers 12345
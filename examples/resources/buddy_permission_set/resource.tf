@@ -0,0 +1,7 @@
+resource "buddy_permission_set" "release_managers" {
+  name                    = "Release managers"
+  description             = "Can run the release pipelines"
+  pipeline_access_level   = "RUN_ONLY"
+  repository_access_level = "READ_ONLY"
+  sandbox_access_level    = "DENIED"
+}
@@ -0,0 +1,3 @@
+# import existing pipeline using project name and pipeline ID separated by colon
+# You can get a pipeline ID from the pipeline URL.
+terraform import buddy_pipeline.build 'my-project:12345'
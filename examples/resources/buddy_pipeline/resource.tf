@@ -0,0 +1,24 @@
+resource "buddy_pipeline" "build" {
+  project_name = "my-project"
+  name         = "Build"
+  on           = "EVENT"
+  priority     = "HIGH"
+
+  event {
+    type = "PUSH"
+    refs = ["refs/heads/master"]
+  }
+
+  trigger_condition {
+    condition = "ON_CHANGE_AT_PATH"
+    paths     = ["src/"]
+  }
+}
+
+resource "buddy_pipeline" "nightly" {
+  project_name = "my-project"
+  name         = "Nightly"
+  on           = "SCHEDULE"
+  refs         = ["refs/heads/master"]
+  cron         = "0 2 * * *"
+}
@@ -0,0 +1,3 @@
+# import existing action using project name, pipeline ID and action ID separated by colon
+# You can get the IDs from the action URL.
+terraform import buddy_pipeline_action.test 'my-project:12345:67890'
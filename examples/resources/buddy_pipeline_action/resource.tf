@@ -0,0 +1,34 @@
+resource "buddy_pipeline" "build" {
+  project_name = "my-project"
+  name         = "Build"
+  on           = "CLICK"
+}
+
+resource "buddy_pipeline_action" "test" {
+  project_name      = "my-project"
+  pipeline_id       = buddy_pipeline.build.pipeline_id
+  name              = "Run tests"
+  type              = "BUILD"
+  docker_image_name = "library/golang"
+  docker_image_tag  = "1.16"
+  execute_commands  = ["go test ./..."]
+
+  variable {
+    key   = "GOFLAGS"
+    value = "-mod=vendor"
+  }
+}
+
+resource "buddy_pipeline_action" "notify" {
+  project_name    = "my-project"
+  pipeline_id     = buddy_pipeline.build.pipeline_id
+  name            = "Notify on failure"
+  type            = "HTTP"
+  trigger_time    = "ON_FAILURE"
+  after_action_id = buddy_pipeline_action.test.action_id
+
+  settings_json = jsonencode({
+    endpoint = "https://example.com/hooks/build-failed"
+    method   = "POST"
+  })
+}
@@ -0,0 +1,7 @@
+resource "buddy_pipeline_variable" "self" {
+  key         = "TEST_PIPELINE_VAR"
+  value       = "dummy"
+  project     = "example-project"
+  pipeline_id = 12345
+  encrypted   = true
+}
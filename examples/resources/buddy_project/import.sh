@@ -0,0 +1,3 @@
+# import existing project using its name
+# You can get a project name from the project URL.
+terraform import buddy_project.self my-project
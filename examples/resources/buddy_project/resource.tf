@@ -0,0 +1,16 @@
+resource "buddy_project" "self" {
+  display_name = "My Project"
+}
+
+# Project attached to a GitHub repository through a workspace integration
+resource "buddy_project" "github" {
+  display_name        = "Backend"
+  integration_id      = "5e2c3a6b8f0d4e1a"
+  external_project_id = "my-org/backend"
+}
+
+resource "buddy_project_variable" "self" {
+  project = buddy_project.self.name
+  key     = "ENVIRONMENT"
+  value   = "staging"
+}
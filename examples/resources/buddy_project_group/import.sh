@@ -0,0 +1 @@
+terraform import buddy_project_group.developers 'my-project:12345'
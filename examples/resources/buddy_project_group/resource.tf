@@ -0,0 +1,9 @@
+resource "buddy_group" "developers" {
+  name = "Developers"
+}
+
+resource "buddy_project_group" "developers" {
+  project_name      = "my-project"
+  group_id          = buddy_group.developers.id
+  permission_set_id = 12345 # Developer
+}
@@ -6,4 +6,10 @@ resource "buddy_project_member" "self" {
   project_name      = "my-project"
   member_id         = buddy_workspace_member.self.id
   permission_set_id = 12345 # Developer
-}
\ No newline at end of file
+}
+
+resource "buddy_project_member" "by_email" {
+  project_name      = "my-project"
+  email             = "colleague@example.com"
+  permission_set_id = 12345 # Developer
+}
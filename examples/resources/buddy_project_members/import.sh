@@ -0,0 +1 @@
+terraform import buddy_project_members.backend my-project
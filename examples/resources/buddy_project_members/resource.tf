@@ -0,0 +1,21 @@
+data "buddy_permission_set" "developer" {
+  name = "Developer"
+}
+
+data "buddy_permission_set" "read_only" {
+  name = "Read Only"
+}
+
+resource "buddy_project_members" "backend" {
+  project_name = "my-project"
+
+  member {
+    member_id         = "12345"
+    permission_set_id = data.buddy_permission_set.developer.id
+  }
+
+  member {
+    member_id         = "67890"
+    permission_set_id = data.buddy_permission_set.read_only.id
+  }
+}
@@ -1,3 +1,13 @@
 resource "buddy_workspace_member" "self" {
   email = "example@example.com"
-}
\ No newline at end of file
+}
+
+resource "buddy_workspace_member" "new_hire" {
+  email                     = "new.hire@example.com"
+  wait_for_acceptance       = true
+  resend_invitation_trigger = "2021-06-01" # change to send the invitation again
+
+  timeouts {
+    create = "24h"
+  }
+}
@@ -2,4 +2,14 @@ resource "buddy_workspace_variable" "self" {
   key       = "TEST_WORKSPACE_VAR"
   value     = "dummy"
   encrypted = true
+}
+
+resource "buddy_workspace_variable" "deploy_key" {
+  key        = "DEPLOY_KEY"
+  value      = file("~/.ssh/id_deploy")
+  type       = "SSH_KEY"
+  encrypted  = true
+  file_place = "CONTAINER"
+  file_path  = "~/.ssh/id_deploy"
+  file_chmod = "600"
 }
\ No newline at end of file
@@ -2,15 +2,24 @@ package provider
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// listPerPage is the page size requested from list endpoints
+const listPerPage = 100
+
 func newBuddyClient(c *Config) *buddyAdapter {
 	rootCAs, _ := x509.SystemCertPool()
 	if rootCAs == nil {
@@ -21,307 +30,426 @@ func newBuddyClient(c *Config) *buddyAdapter {
 		InsecureSkipVerify: !c.VerifySSL,
 		RootCAs:            rootCAs,
 	}
-	tr := &http.Transport{TLSClientConfig: config}
-	httpClient := &http.Client{Transport: tr}
+	var tr http.RoundTripper = &http.Transport{TLSClientConfig: config}
+	if c.RequestsPerSecond > 0 {
+		// The limiter sits below the retries so every attempt is throttled
+		tr = &rateLimitTransport{base: tr, limiter: newRateLimiter(c.RequestsPerSecond)}
+	}
+	httpClient := &http.Client{
+		Transport: newRetryTransport(tr, c.MaxRetries, time.Duration(c.RetryMaxWait)*time.Second),
+	}
 
 	return &buddyAdapter{BuddyURL: strings.TrimSuffix(c.BuddyURL, "/"), Token: c.Token, Client: httpClient}
 }
 
-func (b *buddyAdapter) CreateWorkspaceVariable(variable buddyRequestWorkspaceVariable) (*buddyResponseWorkspaceVariable, error) {
-	reqBody, err := json.Marshal(&variable)
+func (b *buddyAdapter) CreateWorkspaceVariable(ctx context.Context, variable buddyRequestWorkspaceVariable) (*buddyResponseWorkspaceVariable, error) {
+	var data buddyResponseWorkspaceVariable
+	err := b.do(ctx, http.MethodPost, "variables", nil, &variable, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%v/%v", b.BuddyURL, "variables"), bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, err
-	}
+	return &data, nil
+}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", b.Token))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", user_agent)
+func (b *buddyAdapter) ReadWorkspaceVariable(ctx context.Context, id string) (*buddyResponseWorkspaceVariable, error) {
+	urlPath := fmt.Sprintf("variables/%v", id)
+	var data buddyResponseWorkspaceVariable
 
-	resp, err := b.Do(req)
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
+	return &data, nil
+}
+
+func (b *buddyAdapter) UpdateWorkspaceVariable(ctx context.Context, id string, variable buddyRequestWorkspaceVariable) (*buddyResponseWorkspaceVariable, error) {
+	urlPath := fmt.Sprintf("variables/%v", id)
 	var data buddyResponseWorkspaceVariable
-	if resp.StatusCode != 201 {
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("Expected return code is 201 but got %v. Failed to read response body with the following message: %v", resp.StatusCode, err.Error())
-		}
-		return nil, fmt.Errorf("Expected return code is 201 but got %v with the following response body %v", resp.StatusCode, string(body))
-	}
 
-	err = json.NewDecoder(resp.Body).Decode(&data)
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &variable, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
+
 	return &data, nil
 }
 
-func (b *buddyAdapter) ReadWorkspaceVariable(id string) (*buddyResponseWorkspaceVariable, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%v/%v/%v", b.BuddyURL, "variables", id), nil)
+func (b *buddyAdapter) CreateProjectVariable(ctx context.Context, variable buddyRequestProjectVariable) (*buddyResponseProjectVariable, error) {
+	var data buddyResponseProjectVariable
+	err := b.do(ctx, http.MethodPost, "variables", nil, &variable, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", b.Token))
-	req.Header.Set("User-Agent", user_agent)
+	return &data, nil
+}
 
-	resp, err := b.Do(req)
+func (b *buddyAdapter) ReadProjectVariable(ctx context.Context, id string) (*buddyResponseProjectVariable, error) {
+	urlPath := fmt.Sprintf("variables/%v", id)
+	var data buddyResponseProjectVariable
+
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var data buddyResponseWorkspaceVariable
-	if resp.StatusCode == 404 {
-		return &data, nil
-	}
+	return &data, nil
+}
 
-	if resp.StatusCode != 200 {
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("Expected return code is 200 but got %v. Failed to read response body with the following message: %v", resp.StatusCode, err.Error())
-		}
-		return nil, fmt.Errorf("Expected return code is 200 but got %v with the following response body %v", resp.StatusCode, string(body))
-	}
+func (b *buddyAdapter) UpdateProjectVariable(ctx context.Context, id string, variable buddyRequestProjectVariable) (*buddyResponseProjectVariable, error) {
+	urlPath := fmt.Sprintf("variables/%v", id)
+	var data buddyResponseProjectVariable
 
-	err = json.NewDecoder(resp.Body).Decode(&data)
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &variable, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
+
 	return &data, nil
 }
 
-func (b *buddyAdapter) UpdateWorkspaceVariable(id string, variable buddyRequestWorkspaceVariable) (*buddyResponseWorkspaceVariable, error) {
-	reqBody, err := json.Marshal(&variable)
+func (b *buddyAdapter) CreatePipelineVariable(ctx context.Context, variable buddyRequestPipelineVariable) (*buddyResponsePipelineVariable, error) {
+	var data buddyResponsePipelineVariable
+	err := b.do(ctx, http.MethodPost, "variables", nil, &variable, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 
+	return &data, nil
+}
+
+func (b *buddyAdapter) ReadPipelineVariable(ctx context.Context, id string) (*buddyResponsePipelineVariable, error) {
 	urlPath := fmt.Sprintf("variables/%v", id)
+	var data buddyResponsePipelineVariable
 
-	response, err := b.doPatch(urlPath, reqBody)
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
 
-	var data buddyResponseWorkspaceVariable
+	return &data, nil
+}
 
-	err = json.NewDecoder(bytes.NewReader(response)).Decode(&data)
+func (b *buddyAdapter) UpdatePipelineVariable(ctx context.Context, id string, variable buddyRequestPipelineVariable) (*buddyResponsePipelineVariable, error) {
+	urlPath := fmt.Sprintf("variables/%v", id)
+	var data buddyResponsePipelineVariable
+
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &variable, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
+
 	return &data, nil
 }
 
-func (b *buddyAdapter) CreateProjectVariable(variable buddyRequestProjectVariable) (*buddyResponseProjectVariable, error) {
-	reqBody, err := json.Marshal(&variable)
+func (b *buddyAdapter) CreateActionVariable(ctx context.Context, variable buddyRequestActionVariable) (*buddyResponseActionVariable, error) {
+	var data buddyResponseActionVariable
+	err := b.do(ctx, http.MethodPost, "variables", nil, &variable, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%v/%v", b.BuddyURL, "variables"), bytes.NewBuffer(reqBody))
+	return &data, nil
+}
+
+func (b *buddyAdapter) ReadActionVariable(ctx context.Context, id string) (*buddyResponseActionVariable, error) {
+	urlPath := fmt.Sprintf("variables/%v", id)
+	var data buddyResponseActionVariable
+
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", b.Token))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", user_agent)
+	return &data, nil
+}
 
-	resp, err := b.Do(req)
+func (b *buddyAdapter) UpdateActionVariable(ctx context.Context, id string, variable buddyRequestActionVariable) (*buddyResponseActionVariable, error) {
+	urlPath := fmt.Sprintf("variables/%v", id)
+	var data buddyResponseActionVariable
+
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &variable, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var data buddyResponseProjectVariable
-	if resp.StatusCode != 201 {
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("Expected return code is 201 but got %v. Failed to read response body with the following message: %v", resp.StatusCode, err.Error())
-		}
-		return nil, fmt.Errorf("Expected return code is 201 but got %v with the following response body %v", resp.StatusCode, string(body))
+	return &data, nil
+}
+
+func (b *buddyAdapter) CreateEnvironmentVariable(ctx context.Context, variable buddyRequestEnvironmentVariable) (*buddyResponseEnvironmentVariable, error) {
+	var data buddyResponseEnvironmentVariable
+	err := b.do(ctx, http.MethodPost, "variables", nil, &variable, &data, http.StatusCreated)
+	if err != nil {
+		return nil, err
 	}
 
-	err = json.NewDecoder(resp.Body).Decode(&data)
+	return &data, nil
+}
+
+func (b *buddyAdapter) ReadEnvironmentVariable(ctx context.Context, id string) (*buddyResponseEnvironmentVariable, error) {
+	urlPath := fmt.Sprintf("variables/%v", id)
+	var data buddyResponseEnvironmentVariable
+
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
+
 	return &data, nil
 }
 
-func (b *buddyAdapter) ReadProjectVariable(id string) (*buddyResponseProjectVariable, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%v/%v/%v", b.BuddyURL, "variables", id), nil)
+func (b *buddyAdapter) UpdateEnvironmentVariable(ctx context.Context, id string, variable buddyRequestEnvironmentVariable) (*buddyResponseEnvironmentVariable, error) {
+	urlPath := fmt.Sprintf("variables/%v", id)
+	var data buddyResponseEnvironmentVariable
+
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &variable, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", b.Token))
-	req.Header.Set("User-Agent", user_agent)
+	return &data, nil
+}
 
-	resp, err := b.Do(req)
+func (b *buddyAdapter) DeleteVariable(ctx context.Context, id string) error {
+	urlPath := fmt.Sprintf("variables/%v", id)
+
+	return b.do(ctx, http.MethodDelete, urlPath, nil, nil, nil, http.StatusNoContent)
+}
+
+func (b *buddyAdapter) CreateWorkspaceMember(ctx context.Context, email string) (*buddyResponseWorkspaceMember, error) {
+	reqBody := struct {
+		Email string `json:"email"`
+	}{
+		Email: email,
+	}
+	var data buddyResponseWorkspaceMember
+
+	err := b.do(ctx, http.MethodPost, "members", nil, &reqBody, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var data buddyResponseProjectVariable
-	if resp.StatusCode == 404 {
-		return &data, nil
+	return &data, nil
+}
+
+func (b *buddyAdapter) ReadWorkspaceMember(ctx context.Context, id string) (*buddyResponseWorkspaceMember, error) {
+	urlPath := fmt.Sprintf("members/%v", id)
+	var data buddyResponseWorkspaceMember
+
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
+	if err != nil {
+		return nil, err
 	}
 
-	if resp.StatusCode != 200 {
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("Expected return code is 200 but got %v. Failed to read response body with the following message: %v", resp.StatusCode, err.Error())
-		}
-		return nil, fmt.Errorf("Expected return code is 200 but got %v with the following response body %v", resp.StatusCode, string(body))
+	return &data, nil
+}
+
+func (b *buddyAdapter) DeleteWorkspaceMember(ctx context.Context, id string) error {
+	urlPath := fmt.Sprintf("members/%v", id)
+
+	return b.do(ctx, http.MethodDelete, urlPath, nil, nil, nil, http.StatusNoContent)
+}
+
+func (b *buddyAdapter) SetAdminRight(ctx context.Context, id string, admin bool) (*buddyResponseWorkspaceMember, error) {
+	reqBody := struct {
+		Admin bool `json:"admin"`
+	}{
+		Admin: admin,
 	}
+	urlPath := fmt.Sprintf("members/%v", id)
+	var data buddyResponseWorkspaceMember
 
-	err = json.NewDecoder(resp.Body).Decode(&data)
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &reqBody, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
+
 	return &data, nil
 }
 
-func (b *buddyAdapter) UpdateProjectVariable(id string, variable buddyRequestProjectVariable) (*buddyResponseProjectVariable, error) {
-	reqBody, err := json.Marshal(&variable)
+// ResendInvitation sends the invitation email again to a member who hasn't accepted it yet
+func (b *buddyAdapter) ResendInvitation(ctx context.Context, id string) error {
+	urlPath := fmt.Sprintf("members/%v/resend-invitation", id)
+
+	return b.do(ctx, http.MethodPost, urlPath, nil, nil, nil, http.StatusNoContent)
+}
+
+func (b *buddyAdapter) CreateProjectMember(ctx context.Context, projectName string, variable buddyRequestProjectMember) (*buddyResponseProjectMember, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v", "projects", projectName, "members")
+	var data buddyResponseProjectMember
+
+	err := b.do(ctx, http.MethodPost, urlPath, nil, &variable, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 
-	urlPath := fmt.Sprintf("variables/%v", id)
+	return &data, nil
+}
+
+func (b *buddyAdapter) ReadProjectMember(ctx context.Context, projectName string, memberId string) (*buddyResponseProjectMember, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "members", memberId)
+	var data buddyResponseProjectMember
 
-	response, err := b.doPatch(urlPath, reqBody)
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
 
-	var data buddyResponseProjectVariable
+	return &data, nil
+}
 
-	err = json.NewDecoder(bytes.NewReader(response)).Decode(&data)
+func (b *buddyAdapter) UpdateProjectMember(ctx context.Context, projectName string, memberId string, variable buddyRequestPermissionSet) (*buddyResponseProjectMember, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "members", memberId)
+	var data buddyResponseProjectMember
+
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &variable, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
+
 	return &data, nil
 }
 
-func (b *buddyAdapter) DeleteVariable(id string) error {
-	urlPath := fmt.Sprintf("variables/%v", id)
+func (b *buddyAdapter) DeleteProjectMember(ctx context.Context, projectName string, memberId string) error {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "members", memberId)
 
-	return b.doDelete(urlPath)
+	return b.do(ctx, http.MethodDelete, urlPath, nil, nil, nil, http.StatusNoContent)
 }
 
-func (b *buddyAdapter) CreateWorkspaceMember(email string) (*buddyResponseWorkspaceMember, error) {
-	reqBody, err := json.Marshal(&struct {
-		Email string `json:"email"`
-	}{
-		Email: email,
+// ListProjectMembers returns all members of the project. The list doesn't include the
+// permission set of the members, use ReadProjectMember to get it.
+func (b *buddyAdapter) ListProjectMembers(ctx context.Context, projectName string) ([]buddyWorkspaceMember, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v", "projects", projectName, "members")
+	var members []buddyWorkspaceMember
+
+	err := b.paginate(ctx, urlPath, nil, func(page json.RawMessage) (int, error) {
+		var data buddyResponseListProjectMember
+		if err := json.Unmarshal(page, &data); err != nil {
+			return 0, err
+		}
+
+		members = append(members, data.Members...)
+		return len(data.Members), nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%v/%v", b.BuddyURL, "members"), bytes.NewBuffer(reqBody))
+	return members, nil
+}
+
+func (b *buddyAdapter) CreateProjectGroup(ctx context.Context, projectName string, group buddyRequestProjectGroup) (*buddyResponseProjectGroup, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v", "projects", projectName, "groups")
+	var data buddyResponseProjectGroup
+
+	err := b.do(ctx, http.MethodPost, urlPath, nil, &group, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", b.Token))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", user_agent)
+	return &data, nil
+}
 
-	resp, err := b.Do(req)
+func (b *buddyAdapter) ReadProjectGroup(ctx context.Context, projectName string, groupId string) (*buddyResponseProjectGroup, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "groups", groupId)
+	var data buddyResponseProjectGroup
+
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var data buddyResponseWorkspaceMember
-	if resp.StatusCode != 201 {
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("Expected return code is 201 but got %v. Failed to read response body with the following message: %v", resp.StatusCode, err.Error())
-		}
-		return nil, fmt.Errorf("Expected return code is 201 but got %v with the following response body %v", resp.StatusCode, string(body))
-	}
+	return &data, nil
+}
+
+func (b *buddyAdapter) UpdateProjectGroup(ctx context.Context, projectName string, groupId string, permissionSet buddyRequestPermissionSet) (*buddyResponseProjectGroup, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "groups", groupId)
+	var data buddyResponseProjectGroup
 
-	err = json.NewDecoder(resp.Body).Decode(&data)
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &permissionSet, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
+
 	return &data, nil
 }
 
-func (b *buddyAdapter) ReadWorkspaceMember(id string) (*buddyResponseWorkspaceMember, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%v/%v/%v", b.BuddyURL, "members", id), nil)
+func (b *buddyAdapter) DeleteProjectGroup(ctx context.Context, projectName string, groupId string) error {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "groups", groupId)
+
+	return b.do(ctx, http.MethodDelete, urlPath, nil, nil, nil, http.StatusNoContent)
+}
+
+func (b *buddyAdapter) CreatePermissionSet(ctx context.Context, permissionSet buddyRequestCustomPermissionSet) (*buddyPermissionSet, error) {
+	var data buddyPermissionSet
+
+	err := b.do(ctx, http.MethodPost, "permissions", nil, &permissionSet, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", b.Token))
-	req.Header.Set("User-Agent", user_agent)
+	return &data, nil
+}
 
-	resp, err := b.Do(req)
+func (b *buddyAdapter) ReadPermissionSet(ctx context.Context, id string) (*buddyPermissionSet, error) {
+	urlPath := fmt.Sprintf("permissions/%v", id)
+	var data buddyPermissionSet
+
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var data buddyResponseWorkspaceMember
-	if resp.StatusCode == 404 {
-		return &data, nil
-	}
+	return &data, nil
+}
 
-	if resp.StatusCode != 200 {
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("Expected return code is 200 but got %v. Failed to read response body with the following message: %v", resp.StatusCode, err.Error())
-		}
-		return nil, fmt.Errorf("Expected return code is 200 but got %v with the following response body %v", resp.StatusCode, string(body))
-	}
+func (b *buddyAdapter) UpdatePermissionSet(ctx context.Context, id string, permissionSet buddyRequestCustomPermissionSet) (*buddyPermissionSet, error) {
+	urlPath := fmt.Sprintf("permissions/%v", id)
+	var data buddyPermissionSet
 
-	err = json.NewDecoder(resp.Body).Decode(&data)
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &permissionSet, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
+
 	return &data, nil
 }
 
-func (b *buddyAdapter) DeleteWorkspaceMember(id string) error {
-	urlPath := fmt.Sprintf("members/%v", id)
+func (b *buddyAdapter) DeletePermissionSet(ctx context.Context, id string) error {
+	urlPath := fmt.Sprintf("permissions/%v", id)
 
-	return b.doDelete(urlPath)
+	return b.do(ctx, http.MethodDelete, urlPath, nil, nil, nil, http.StatusNoContent)
 }
 
-func (b *buddyAdapter) SetAdminRight(id string, admin bool) (*buddyResponseWorkspaceMember, error) {
-	reqBody, err := json.Marshal(&struct {
-		Admin bool `json:"admin"`
-	}{
-		Admin: admin,
-	})
+func (b *buddyAdapter) ListPermissionSets(ctx context.Context) ([]buddyPermissionSet, error) {
+	var data buddyResponseListPermissionSet
+
+	err := b.do(ctx, http.MethodGet, "permissions", nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
 
-	urlPath := fmt.Sprintf("members/%v", id)
+	return data.PermissionSets, nil
+}
 
-	response, err := b.doPatch(urlPath, reqBody)
+func (b *buddyAdapter) CreateGroup(ctx context.Context, group buddyRequestGroup) (*buddyResponseGroup, error) {
+	var data buddyResponseGroup
+
+	err := b.do(ctx, http.MethodPost, "groups", nil, &group, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 
-	var data buddyResponseWorkspaceMember
-	err = json.NewDecoder(bytes.NewReader(response)).Decode(&data)
+	return &data, nil
+}
+
+func (b *buddyAdapter) ReadGroup(ctx context.Context, id string) (*buddyResponseGroup, error) {
+	urlPath := fmt.Sprintf("groups/%v", id)
+	var data buddyResponseGroup
+
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
@@ -329,20 +457,41 @@ func (b *buddyAdapter) SetAdminRight(id string, admin bool) (*buddyResponseWorks
 	return &data, nil
 }
 
-func (b *buddyAdapter) CreateProjectMember(projectName string, variable buddyRequestProjectMember) (*buddyResponseProjectMember, error) {
-	reqBody, err := json.Marshal(&variable)
+func (b *buddyAdapter) UpdateGroup(ctx context.Context, id string, group buddyRequestGroup) (*buddyResponseGroup, error) {
+	urlPath := fmt.Sprintf("groups/%v", id)
+	var data buddyResponseGroup
+
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &group, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
 
-	urlPath := fmt.Sprintf("%v/%v/%v", "projects", projectName, "members")
-	response, err := b.doCreate(urlPath, reqBody)
+	return &data, nil
+}
+
+func (b *buddyAdapter) DeleteGroup(ctx context.Context, id string) error {
+	urlPath := fmt.Sprintf("groups/%v", id)
+
+	return b.do(ctx, http.MethodDelete, urlPath, nil, nil, nil, http.StatusNoContent)
+}
+
+func (b *buddyAdapter) CreateGroupMember(ctx context.Context, groupId string, memberId string) (*buddyResponseWorkspaceMember, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v", "groups", groupId, "members")
+	var data buddyResponseWorkspaceMember
+
+	err := b.do(ctx, http.MethodPost, urlPath, nil, &buddyRequestGroupMember{Id: memberId}, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 
-	var data buddyResponseProjectMember
-	err = json.NewDecoder(bytes.NewReader(response)).Decode(&data)
+	return &data, nil
+}
+
+func (b *buddyAdapter) ReadGroupMember(ctx context.Context, groupId string, memberId string) (*buddyResponseWorkspaceMember, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v", "groups", groupId, "members", memberId)
+	var data buddyResponseWorkspaceMember
+
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
@@ -350,20 +499,28 @@ func (b *buddyAdapter) CreateProjectMember(projectName string, variable buddyReq
 	return &data, nil
 }
 
-func (b *buddyAdapter) ReadProjectMember(projectName string, memberId string) (*buddyResponseProjectMember, error) {
-	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "members", memberId)
-	var data buddyResponseProjectMember
+func (b *buddyAdapter) DeleteGroupMember(ctx context.Context, groupId string, memberId string) error {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v", "groups", groupId, "members", memberId)
+
+	return b.do(ctx, http.MethodDelete, urlPath, nil, nil, nil, http.StatusNoContent)
+}
+
+func (b *buddyAdapter) CreateProject(ctx context.Context, project buddyRequestCreateProject) (*buddyResponseProject, error) {
+	var data buddyResponseProject
 
-	response, err := b.doRead(urlPath)
+	err := b.do(ctx, http.MethodPost, "projects", nil, &project, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 
-	if response == nil {
-		return &data, nil
-	}
+	return &data, nil
+}
 
-	err = json.NewDecoder(bytes.NewReader(response)).Decode(&data)
+func (b *buddyAdapter) ReadProject(ctx context.Context, projectName string) (*buddyResponseProject, error) {
+	urlPath := fmt.Sprintf("%v/%v", "projects", projectName)
+	var data buddyResponseProject
+
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
@@ -371,21 +528,52 @@ func (b *buddyAdapter) ReadProjectMember(projectName string, memberId string) (*
 	return &data, nil
 }
 
-func (b *buddyAdapter) UpdateProjectMember(projectName string, memberId string, variable buddyRequestPermissionSet) (*buddyResponseProjectMember, error) {
-	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "members", memberId)
-	var data buddyResponseProjectMember
+func (b *buddyAdapter) UpdateProject(ctx context.Context, projectName string, project buddyRequestUpdateProject) (*buddyResponseProject, error) {
+	urlPath := fmt.Sprintf("%v/%v", "projects", projectName)
+	var data buddyResponseProject
 
-	reqBody, err := json.Marshal(&variable)
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &project, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
 
-	response, err := b.doPatch(urlPath, reqBody)
+	return &data, nil
+}
+
+func (b *buddyAdapter) DeleteProject(ctx context.Context, projectName string) error {
+	urlPath := fmt.Sprintf("%v/%v", "projects", projectName)
+
+	return b.do(ctx, http.MethodDelete, urlPath, nil, nil, nil, http.StatusNoContent)
+}
+
+func (b *buddyAdapter) ListProjects(ctx context.Context, status string) ([]buddyProject, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", status)
+	}
+	var projects []buddyProject
+
+	err := b.paginate(ctx, "projects", query, func(page json.RawMessage) (int, error) {
+		var data buddyResponseListProject
+		if err := json.Unmarshal(page, &data); err != nil {
+			return 0, err
+		}
+
+		projects = append(projects, data.Projects...)
+		return len(data.Projects), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.NewDecoder(bytes.NewReader(response)).Decode(&data)
+	return projects, nil
+}
+
+func (b *buddyAdapter) CreatePipeline(ctx context.Context, projectName string, pipeline buddyRequestPipeline) (*buddyResponsePipeline, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v", "projects", projectName, "pipelines")
+	var data buddyResponsePipeline
+
+	err := b.do(ctx, http.MethodPost, urlPath, nil, &pipeline, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
@@ -393,38 +581,53 @@ func (b *buddyAdapter) UpdateProjectMember(projectName string, memberId string,
 	return &data, nil
 }
 
-func (b *buddyAdapter) DeleteProjectMember(projectName string, memberId string) error {
-	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "members", memberId)
+func (b *buddyAdapter) ReadPipeline(ctx context.Context, projectName string, pipelineId string) (*buddyResponsePipeline, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "pipelines", pipelineId)
+	var data buddyResponsePipeline
 
-	return b.doDelete(urlPath)
-}
-
-func (b *buddyAdapter) GetUser(email string) (*buddyWorkspaceMember, error) {
-	response, err := b.listUsers(1, 1000)
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
 
-	var data buddyWorkspaceMember
-	for _, member := range response.Members {
-		if member.Email == email {
-			data = member
-		}
+	return &data, nil
+}
+
+func (b *buddyAdapter) UpdatePipeline(ctx context.Context, projectName string, pipelineId string, pipeline buddyRequestPipeline) (*buddyResponsePipeline, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "pipelines", pipelineId)
+	var data buddyResponsePipeline
+
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &pipeline, &data, http.StatusOK)
+	if err != nil {
+		return nil, err
 	}
 
 	return &data, nil
 }
 
-func (b *buddyAdapter) listUsers(pageNo int, userPerPage int) (*buddyResponseListWorkspaceMember, error) {
-	urlPath := fmt.Sprintf("members?page=%v&per_page=%v&sort_name=name", pageNo, userPerPage)
-	var data buddyResponseListWorkspaceMember
+func (b *buddyAdapter) DeletePipeline(ctx context.Context, projectName string, pipelineId string) error {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v", "projects", projectName, "pipelines", pipelineId)
 
-	response, err := b.doRead(urlPath)
+	return b.do(ctx, http.MethodDelete, urlPath, nil, nil, nil, http.StatusNoContent)
+}
+
+func (b *buddyAdapter) CreatePipelineAction(ctx context.Context, projectName string, pipelineId string, action buddyRequestPipelineAction) (*buddyResponsePipelineAction, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v/%v", "projects", projectName, "pipelines", pipelineId, "actions")
+	var data buddyResponsePipelineAction
+
+	err := b.do(ctx, http.MethodPost, urlPath, nil, &action, &data, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.NewDecoder(bytes.NewReader(response)).Decode(&data)
+	return &data, nil
+}
+
+func (b *buddyAdapter) ReadPipelineAction(ctx context.Context, projectName string, pipelineId string, actionId string) (*buddyResponsePipelineAction, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v/%v/%v", "projects", projectName, "pipelines", pipelineId, "actions", actionId)
+	var data buddyResponsePipelineAction
+
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
@@ -432,117 +635,270 @@ func (b *buddyAdapter) listUsers(pageNo int, userPerPage int) (*buddyResponseLis
 	return &data, nil
 }
 
-func (b *buddyAdapter) doRead(urlPath string) ([]byte, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%v/%v", b.BuddyURL, urlPath), nil)
+func (b *buddyAdapter) UpdatePipelineAction(ctx context.Context, projectName string, pipelineId string, actionId string, action buddyRequestPipelineAction) (*buddyResponsePipelineAction, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v/%v/%v", "projects", projectName, "pipelines", pipelineId, "actions", actionId)
+	var data buddyResponsePipelineAction
+
+	err := b.do(ctx, http.MethodPatch, urlPath, nil, &action, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", b.Token))
-	req.Header.Set("User-Agent", user_agent)
+	return &data, nil
+}
 
-	resp, err := b.Do(req)
+func (b *buddyAdapter) DeletePipelineAction(ctx context.Context, projectName string, pipelineId string, actionId string) error {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v/%v/%v", "projects", projectName, "pipelines", pipelineId, "actions", actionId)
+
+	return b.do(ctx, http.MethodDelete, urlPath, nil, nil, nil, http.StatusNoContent)
+}
+
+func (b *buddyAdapter) ListPipelineActions(ctx context.Context, projectName string, pipelineId string) ([]buddyResponsePipelineAction, error) {
+	urlPath := fmt.Sprintf("%v/%v/%v/%v/%v", "projects", projectName, "pipelines", pipelineId, "actions")
+	var data buddyResponseListPipelineAction
+
+	err := b.do(ctx, http.MethodGet, urlPath, nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var data []byte
+	return data.Actions, nil
+}
+
+// MarshalJSON sends the schedule of SCHEDULE pipelines even when empty, so a cleared cron,
+// delay or start date is removed. Other pipelines only send the schedule when set.
+func (p buddyRequestPipeline) MarshalJSON() ([]byte, error) {
+	type typedPipeline buddyRequestPipeline
 
-	if resp.StatusCode == 404 {
-		return data, nil
+	data, err := json.Marshal(typedPipeline(p))
+	if err != nil || p.On != "SCHEDULE" {
+		return data, err
 	}
 
-	data, err = ioutil.ReadAll(resp.Body)
+	body := map[string]interface{}{}
+	err = json.Unmarshal(data, &body)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to read response body while performing doRead with the following error message: %v", err.Error())
+		return nil, err
 	}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Expected return code is 200 but got %v with the following response body %v", resp.StatusCode, string(data))
-	}
+	body["cron"] = p.Cron
+	body["delay"] = p.Delay
+	body["start_date"] = p.StartDate
 
-	return data, nil
+	return json.Marshal(body)
 }
 
-func (b *buddyAdapter) doCreate(urlPath string, reqBody []byte) ([]byte, error) {
-	req, err := http.NewRequest("POST", fmt.Sprintf("%v/%v", b.BuddyURL, urlPath), bytes.NewBuffer(reqBody))
+// MarshalJSON sends the action specific settings next to the typed fields.
+// The typed fields take precedence over settings with the same name. The commands
+// and the image are only sent when set, unless the action type runs commands.
+func (a buddyRequestPipelineAction) MarshalJSON() ([]byte, error) {
+	type typedAction buddyRequestPipelineAction
+
+	typed, err := json.Marshal(typedAction(a))
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", b.Token))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", user_agent)
+	body := map[string]interface{}{}
+	for key, value := range a.Settings {
+		body[key] = value
+	}
 
-	resp, err := b.Do(req)
+	err = json.Unmarshal(typed, &body)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to read response body while performing doCreate with the following error message: %v", err.Error())
+	if pipelineActionCommandTypes[a.Type] {
+		commands := a.ExecuteCommands
+		if commands == nil {
+			commands = []string{}
+		}
+		body["execute_commands"] = commands
+		body["docker_image_name"] = a.DockerImageName
+		body["docker_image_tag"] = a.DockerImageTag
 	}
 
-	if resp.StatusCode != 201 {
-		return nil, fmt.Errorf("Expected return code is 201 but got %v with the following response body %v", resp.StatusCode, string(data))
+	return json.Marshal(body)
+}
+
+// UnmarshalJSON decodes the typed fields and keeps the whole action in Raw
+func (a *buddyResponsePipelineAction) UnmarshalJSON(data []byte) error {
+	type typedAction buddyResponsePipelineAction
+
+	var typed typedAction
+	err := json.Unmarshal(data, &typed)
+	if err != nil {
+		return err
 	}
 
-	return data, nil
+	*a = buddyResponsePipelineAction(typed)
+	return json.Unmarshal(data, &a.Raw)
 }
 
-func (b *buddyAdapter) doPatch(urlPath string, reqBody []byte) ([]byte, error) {
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("%v/%v", b.BuddyURL, urlPath), bytes.NewBuffer(reqBody))
+// GetUser looks up the workspace member with the given email, ignoring case.
+// An empty member is returned when nobody matches.
+func (b *buddyAdapter) GetUser(ctx context.Context, email string) (*buddyWorkspaceMember, error) {
+	members, err := b.ListMembers(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", b.Token))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", user_agent)
+	var matches []buddyWorkspaceMember
+	for _, member := range members {
+		if strings.EqualFold(member.Email, email) {
+			matches = append(matches, member)
+		}
+	}
 
-	resp, err := b.Do(req)
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("Found %v workspace members with email %v", len(matches), email)
+	}
+
+	var data buddyWorkspaceMember
+	if len(matches) == 1 {
+		data = matches[0]
+	}
+
+	return &data, nil
+}
+
+// GetCurrentUser returns the user the token belongs to
+func (b *buddyAdapter) GetCurrentUser(ctx context.Context) (*buddyWorkspaceMember, error) {
+	var data buddyWorkspaceMember
+	err := b.do(ctx, http.MethodGet, "/user", nil, nil, &data, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	data, err := ioutil.ReadAll(resp.Body)
+	return &data, nil
+}
+
+func (b *buddyAdapter) ListMembers(ctx context.Context) ([]buddyWorkspaceMember, error) {
+	query := url.Values{}
+	query.Set("sort_name", "name")
+	var members []buddyWorkspaceMember
+
+	err := b.paginate(ctx, "members", query, func(page json.RawMessage) (int, error) {
+		var data buddyResponseListWorkspaceMember
+		if err := json.Unmarshal(page, &data); err != nil {
+			return 0, err
+		}
+
+		members = append(members, data.Members...)
+		return len(data.Members), nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("Failed to read response body with the following error message: %v", err.Error())
+		return nil, err
 	}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Expected return code is 200 but got %v with the following response body %v", resp.StatusCode, string(data))
+	return members, nil
+}
+
+// apiRootURL returns buddy_url without the workspace part
+func (b *buddyAdapter) apiRootURL() string {
+	if i := strings.LastIndex(b.BuddyURL, "/workspaces/"); i >= 0 {
+		return b.BuddyURL[:i]
 	}
 
-	return data, nil
+	return b.BuddyURL
+}
+
+// paginate walks the pages of a list endpoint. Every page is passed to collect, which
+// decodes it and returns the number of items on the page. The walk stops at the first
+// page that isn't full.
+func (b *buddyAdapter) paginate(ctx context.Context, urlPath string, query url.Values, collect func(page json.RawMessage) (int, error)) error {
+	for pageNo := 1; ; pageNo++ {
+		pageQuery := url.Values{}
+		for key, values := range query {
+			pageQuery[key] = values
+		}
+		pageQuery.Set("page", strconv.Itoa(pageNo))
+		pageQuery.Set("per_page", strconv.Itoa(listPerPage))
+
+		var page json.RawMessage
+		err := b.do(ctx, http.MethodGet, urlPath, pageQuery, nil, &page, http.StatusOK)
+		if err != nil {
+			return err
+		}
+
+		count, err := collect(page)
+		if err != nil {
+			return err
+		}
+
+		if count < listPerPage {
+			return nil
+		}
+	}
 }
 
-func (b *buddyAdapter) doDelete(urlPath string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%v/%v", b.BuddyURL, urlPath), nil)
+// do sends a request to the Buddy API and is the only place where requests are built.
+// The body is encoded as JSON when not nil, and the response is decoded into out when
+// Buddy returns one of the expected status codes. Other status codes are returned as BuddyAPIError.
+// A urlPath starting with a slash is relative to the API root instead of the workspace.
+func (b *buddyAdapter) do(ctx context.Context, method string, urlPath string, query url.Values, body interface{}, out interface{}, expected ...int) error {
+	reqURL := fmt.Sprintf("%v/%v", b.BuddyURL, urlPath)
+	if strings.HasPrefix(urlPath, "/") {
+		reqURL = b.apiRootURL() + urlPath
+	}
+	if len(query) > 0 {
+		reqURL = fmt.Sprintf("%v?%v", reqURL, query.Encode())
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", b.Token))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", b.Token))
 	req.Header.Set("User-Agent", user_agent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
+	log.Printf("[DEBUG] Sending %v %v", method, req.URL.Path)
 	resp, err := b.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
+	log.Printf("[DEBUG] %v %v returned %v", method, req.URL.Path, resp.StatusCode)
 
-	if resp.StatusCode != 204 {
-		body, err := ioutil.ReadAll(resp.Body)
+	if !isExpectedStatus(resp.StatusCode, expected) {
+		data, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
-			return fmt.Errorf("Expected return code is 204 but got %v. Failed to read response body with the following message: %v", resp.StatusCode, err.Error())
+			return fmt.Errorf("%v %v returned %v. Failed to read response body with the following message: %v", method, req.URL.Path, resp.StatusCode, err.Error())
 		}
-		return fmt.Errorf("Expected return code is 204 but got %v with the following response body %v", resp.StatusCode, string(body))
+		return newBuddyAPIError(resp, data)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return fmt.Errorf("Failed to decode the response of %v %v: %v", method, req.URL.Path, err.Error())
 	}
 
 	return nil
 }
+
+func isExpectedStatus(status int, expected []int) bool {
+	for _, e := range expected {
+		if status == e {
+			return true
+		}
+	}
+	return false
+}
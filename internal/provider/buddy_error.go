@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// BuddyAPIError is returned when Buddy responds with an unexpected status code
+type BuddyAPIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	RequestID  string
+
+	// Messages holds the errors[].message list of the response
+	Messages []string
+
+	// Body is the raw response body, kept when it doesn't contain Buddy errors
+	Body string
+}
+
+func (e *BuddyAPIError) Error() string {
+	msg := fmt.Sprintf("%v %v returned %v", e.Method, e.Path, e.status())
+	if reason := e.reason(); reason != "" {
+		msg = fmt.Sprintf("%v: %v", msg, reason)
+	}
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%v (request ID %v)", msg, e.RequestID)
+	}
+	return msg
+}
+
+func (e *BuddyAPIError) status() string {
+	if text := http.StatusText(e.StatusCode); text != "" {
+		return fmt.Sprintf("%v %v", e.StatusCode, text)
+	}
+	return fmt.Sprintf("%v", e.StatusCode)
+}
+
+func (e *BuddyAPIError) reason() string {
+	if len(e.Messages) > 0 {
+		return strings.Join(e.Messages, "; ")
+	}
+	return e.Body
+}
+
+// newBuddyAPIError builds the error from a response whose body was already read
+func newBuddyAPIError(resp *http.Response, body []byte) *BuddyAPIError {
+	apiErr := &BuddyAPIError{
+		StatusCode: resp.StatusCode,
+		Method:     resp.Request.Method,
+		Path:       resp.Request.URL.Path,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+
+	var data struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &data); err == nil {
+		for _, e := range data.Errors {
+			if e.Message != "" {
+				apiErr.Messages = append(apiErr.Messages, e.Message)
+			}
+		}
+	}
+
+	if len(apiErr.Messages) == 0 {
+		apiErr.Body = strings.TrimSpace(string(body))
+	}
+
+	return apiErr
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *BuddyAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// IsNotFound reports whether Buddy responded with 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether Buddy responded with 409
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsForbidden reports whether Buddy responded with 403
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// buddyDiagnostics converts an error returned by the client to diagnostics.
+// Buddy API errors get a summary with the Buddy error messages and the request
+// details in the detail, other errors are passed to diag.FromErr.
+func buddyDiagnostics(err error) diag.Diagnostics {
+	var apiErr *BuddyAPIError
+	if !errors.As(err, &apiErr) {
+		return diag.FromErr(err)
+	}
+
+	summary := fmt.Sprintf("Buddy API returned %v", apiErr.status())
+	if reason := apiErr.reason(); reason != "" {
+		summary = fmt.Sprintf("%v: %v", summary, reason)
+	}
+
+	detail := fmt.Sprintf("Request: %v %v", apiErr.Method, apiErr.Path)
+	if apiErr.RequestID != "" {
+		detail = fmt.Sprintf("%v\nRequest ID: %v", detail, apiErr.RequestID)
+	}
+
+	switch {
+	case IsForbidden(err):
+		detail = fmt.Sprintf("%v\n\nCheck that the token has the scopes needed for this operation.", detail)
+	case IsConflict(err):
+		detail = fmt.Sprintf("%v\n\nThe object already exists in Buddy. Import it to manage it with Terraform.", detail)
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   detail,
+		},
+	}
+}
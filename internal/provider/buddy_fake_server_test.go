@@ -0,0 +1,1916 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	fakeBuddyWorkspace = "acme"
+	fakeBuddyToken     = "fake-token"
+	// fakeBuddyUserId is the ID of the user the token belongs to, unless changed
+	fakeBuddyUserId = 1
+)
+
+type fakeVariable struct {
+	Id            int
+	Key           string
+	Value         string
+	Type          string
+	Settable      bool
+	Encrypted     bool
+	Description   string
+	Project       string
+	PipelineId    int
+	ActionId      int
+	EnvironmentId string
+	File          buddyRequestVariableFile
+}
+
+// fakeVariableRequest covers the variable request of every scope
+type fakeVariableRequest struct {
+	buddyRequestVariableFile
+
+	Key         string                  `json:"key"`
+	Value       string                  `json:"value"`
+	Type        string                  `json:"type"`
+	Settable    bool                    `json:"settable"`
+	Encrypted   bool                    `json:"encrypted"`
+	Description string                  `json:"description"`
+	Project     buddyRequestProject     `json:"project"`
+	Pipeline    buddyId                 `json:"pipeline"`
+	Action      buddyId                 `json:"action"`
+	Environment buddyRequestEnvironment `json:"environment"`
+}
+
+type fakeEnvironment struct {
+	Id      string
+	Project string
+	Name    string
+}
+
+type fakeProjectMember struct {
+	MemberId        int
+	PermissionSetId int
+}
+
+type fakeGroup struct {
+	Id      int
+	Group   buddyRequestGroup
+	Members map[int]bool
+}
+
+type fakeProject struct {
+	Name        string
+	DisplayName string
+	Status      string
+	CreateDate  string
+	Members     map[int]*fakeProjectMember
+	// Groups maps the ID of the groups assigned to the project to their permission set ID
+	Groups map[int]int
+}
+
+type fakePipeline struct {
+	Id       int
+	Project  string
+	Pipeline buddyRequestPipeline
+	// Actions holds the action fields in the order of execution
+	Actions []map[string]interface{}
+}
+
+// fakeBuddy is an in-process implementation of the subset of the Buddy REST API
+// used by the provider. It keeps its state in memory so tests can run offline
+// and inspect what the provider did.
+type fakeBuddy struct {
+	mu     sync.Mutex
+	server *httptest.Server
+	nextId int
+
+	variables      map[int]*fakeVariable
+	members        map[int]*buddyResponseWorkspaceMember
+	projects       map[string]*fakeProject
+	pipelines      map[int]*fakePipeline
+	environments   map[string]*fakeEnvironment
+	permissionSets map[int]*buddyPermissionSet
+	groups         map[int]*fakeGroup
+
+	// currentUser is the ID of the user the token belongs to
+	currentUser int
+
+	// invitations counts the invitation emails sent per member ID
+	invitations map[int]int
+
+	// requests counts the requests served per HTTP method
+	requests map[string]int
+}
+
+func newFakeBuddy(t *testing.T) *fakeBuddy {
+	f := &fakeBuddy{
+		nextId:         1000,
+		currentUser:    fakeBuddyUserId,
+		variables:      map[int]*fakeVariable{},
+		members:        map[int]*buddyResponseWorkspaceMember{},
+		invitations:    map[int]int{},
+		projects:       map[string]*fakeProject{},
+		pipelines:      map[int]*fakePipeline{},
+		environments:   map[string]*fakeEnvironment{},
+		requests:       map[string]int{},
+		permissionSets: map[int]*buddyPermissionSet{},
+		groups:         map[int]*fakeGroup{},
+	}
+
+	prefix := "/workspaces/" + fakeBuddyWorkspace
+	mux := http.NewServeMux()
+	mux.Handle(prefix+"/", http.StripPrefix(prefix, http.HandlerFunc(f.serveHTTP)))
+	mux.HandleFunc("/user", f.handleCurrentUser)
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	f.addPermissionSet("Developer", "DEVELOPER")
+	f.addPermissionSet("Read Only", "READ_ONLY")
+	f.addPermissionSet("Project Manager", "PROJECT_MANAGER")
+
+	return f
+}
+
+// URL returns the workspace URL to be used as buddy_url.
+func (f *fakeBuddy) URL() string {
+	return f.server.URL + "/workspaces/" + fakeBuddyWorkspace
+}
+
+func (f *fakeBuddy) client() *buddyAdapter {
+	return newBuddyClient(&Config{
+		BuddyURL:  f.URL(),
+		Token:     fakeBuddyToken,
+		VerifySSL: true,
+	})
+}
+
+func (f *fakeBuddy) providerConfig() string {
+	return fmt.Sprintf(`
+provider "buddy" {
+  buddy_url = %q
+  token     = %q
+}
+`, f.URL(), fakeBuddyToken)
+}
+
+func (f *fakeBuddy) newId() int {
+	f.nextId++
+	return f.nextId
+}
+
+func (f *fakeBuddy) addPermissionSet(name string, permissionType string) *buddyPermissionSet {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newId()
+	ps := &buddyPermissionSet{
+		URL:                   fmt.Sprintf("%v/permissions/%v", f.apiURL(), id),
+		HTMLURL:               fmt.Sprintf("%v/permissions/%v", f.htmlURL(), id),
+		Id:                    id,
+		Name:                  name,
+		Type:                  permissionType,
+		RepositoryAccessLevel: "READ_WRITE",
+		PipelineAccessLevel:   "RUN_ONLY",
+		SandboxAccessLevel:    "READ_ONLY",
+	}
+	f.permissionSets[id] = ps
+	return ps
+}
+
+func (f *fakeBuddy) permissionSetByName(name string) *buddyPermissionSet {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, ps := range f.permissionSets {
+		if ps.Name == name {
+			return ps
+		}
+	}
+	return nil
+}
+
+func (f *fakeBuddy) addMember(email string, name string) *buddyResponseWorkspaceMember {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.createMember(email, name)
+}
+
+func (f *fakeBuddy) createMember(email string, name string) *buddyResponseWorkspaceMember {
+	id := f.newId()
+	member := &buddyResponseWorkspaceMember{
+		Url:              fmt.Sprintf("%v/members/%v", f.apiURL(), id),
+		HTMLURL:          fmt.Sprintf("%v/people/%v", f.htmlURL(), id),
+		Id:               id,
+		Name:             name,
+		AvatarUrl:        fmt.Sprintf("%v/image-server/user/%v/size/64/64", f.server.URL, id),
+		Email:            email,
+		InvitationStatus: "ACCEPTED",
+	}
+	f.members[id] = member
+	return member
+}
+
+func (f *fakeBuddy) addProject(name string) *fakeProject {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.createProject(name, name)
+}
+
+func (f *fakeBuddy) createProject(name string, displayName string) *fakeProject {
+	project := &fakeProject{
+		Name:        name,
+		DisplayName: displayName,
+		Status:      "ACTIVE",
+		CreateDate:  "2021-07-01T10:00:00Z",
+		Members:     map[int]*fakeProjectMember{},
+		Groups:      map[int]int{},
+	}
+	f.projects[name] = project
+	return project
+}
+
+// addEnvironment creates an environment in the project and returns its ID.
+// Environments are not managed by the provider, so they are only seeded by tests.
+func (f *fakeBuddy) addEnvironment(projectName string, name string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	environment := &fakeEnvironment{
+		Id:      fmt.Sprintf("env%v", f.newId()),
+		Project: projectName,
+		Name:    name,
+	}
+	f.environments[environment.Id] = environment
+	return environment.Id
+}
+
+func (f *fakeBuddy) hasProject(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.projects[name]
+	return ok
+}
+
+func (f *fakeBuddy) removeProject(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.deleteProject(name)
+}
+
+func (f *fakeBuddy) deleteProject(name string) {
+	delete(f.projects, name)
+	for id, v := range f.variables {
+		if v.Project == name {
+			delete(f.variables, id)
+		}
+	}
+	for id, p := range f.pipelines {
+		if p.Project == name {
+			delete(f.pipelines, id)
+		}
+	}
+	for id, e := range f.environments {
+		if e.Project == name {
+			delete(f.environments, id)
+		}
+	}
+}
+
+func (f *fakeBuddy) hasPipeline(id int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.pipelines[id]
+	return ok
+}
+
+func (f *fakeBuddy) removePipeline(id int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.deletePipeline(id)
+}
+
+func (f *fakeBuddy) deletePipeline(id int) {
+	delete(f.pipelines, id)
+	for variableId, v := range f.variables {
+		if v.PipelineId == id {
+			delete(f.variables, variableId)
+		}
+	}
+}
+
+func (f *fakeBuddy) requestCount(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests[method]
+}
+
+func (f *fakeBuddy) variableCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.variables)
+}
+
+func (f *fakeBuddy) hasMember(id int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.members[id]
+	return ok
+}
+
+func (f *fakeBuddy) hasProjectMember(projectName string, memberId int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	project, ok := f.projects[projectName]
+	if !ok {
+		return false
+	}
+	_, ok = project.Members[memberId]
+	return ok
+}
+
+// removeVariable deletes a variable behind the provider's back, as if it was
+// removed in the Buddy UI.
+func (f *fakeBuddy) removeVariable(id int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.variables, id)
+}
+
+func (f *fakeBuddy) hasGroup(id int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.groups[id]
+	return ok
+}
+
+func (f *fakeBuddy) hasGroupMember(groupId int, memberId int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, ok := f.groups[groupId]
+	return ok && g.Members[memberId]
+}
+
+func (f *fakeBuddy) removeGroupMember(groupId int, memberId int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if g, ok := f.groups[groupId]; ok {
+		delete(g.Members, memberId)
+	}
+}
+
+func (f *fakeBuddy) hasPermissionSet(id int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.permissionSets[id]
+	return ok
+}
+
+func (f *fakeBuddy) removePermissionSet(id int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.permissionSets, id)
+}
+
+func (f *fakeBuddy) removeGroup(id int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.groups, id)
+	for _, p := range f.projects {
+		delete(p.Groups, id)
+	}
+}
+
+func (f *fakeBuddy) removeMember(id int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.members, id)
+	for _, p := range f.projects {
+		delete(p.Members, id)
+	}
+	for _, g := range f.groups {
+		delete(g.Members, id)
+	}
+}
+
+func (f *fakeBuddy) hasProjectGroup(projectName string, groupId int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	project, ok := f.projects[projectName]
+	if !ok {
+		return false
+	}
+	_, ok = project.Groups[groupId]
+	return ok
+}
+
+func (f *fakeBuddy) removeProjectGroup(projectName string, groupId int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if project, ok := f.projects[projectName]; ok {
+		delete(project.Groups, groupId)
+	}
+}
+
+// inviteMember adds a member who hasn't accepted the invitation yet
+func (f *fakeBuddy) inviteMember(email string) *buddyResponseWorkspaceMember {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	member := f.createMember(email, strings.Split(email, "@")[0])
+	member.InvitationStatus = "PENDING"
+	f.invitations[member.Id]++
+	return member
+}
+
+// acceptInvitation accepts the invitation on behalf of the member
+func (f *fakeBuddy) acceptInvitation(id int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.members[id].InvitationStatus = "ACCEPTED"
+}
+
+func (f *fakeBuddy) invitationCount(id int) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.invitations[id]
+}
+
+// addProjectMember adds a member to the project behind the provider's back, as if it
+// was added in the Buddy UI.
+func (f *fakeBuddy) addProjectMember(projectName string, memberId int, permissionSetId int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.projects[projectName].Members[memberId] = &fakeProjectMember{MemberId: memberId, PermissionSetId: permissionSetId}
+}
+
+func (f *fakeBuddy) removeProjectMember(projectName string, memberId int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if project, ok := f.projects[projectName]; ok {
+		delete(project.Members, memberId)
+	}
+}
+
+func (f *fakeBuddy) apiURL() string {
+	return f.URL()
+}
+
+func (f *fakeBuddy) htmlURL() string {
+	return f.server.URL + "/" + fakeBuddyWorkspace
+}
+
+func (f *fakeBuddy) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+fakeBuddyToken {
+		writeFakeError(w, http.StatusUnauthorized, "Invalid token")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests[r.Method]++
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case segments[0] == "variables" && len(segments) == 1:
+		f.handleVariables(w, r)
+	case segments[0] == "variables" && len(segments) == 2:
+		f.handleVariable(w, r, segments[1])
+	case segments[0] == "members" && len(segments) == 1:
+		f.handleMembers(w, r)
+	case segments[0] == "members" && len(segments) == 2:
+		f.handleMember(w, r, segments[1])
+	case segments[0] == "members" && len(segments) == 3 && segments[2] == "resend-invitation":
+		f.handleResendInvitation(w, r, segments[1])
+	case segments[0] == "permissions" && len(segments) == 1:
+		f.handlePermissionSets(w, r)
+	case segments[0] == "permissions" && len(segments) == 2:
+		f.handlePermissionSet(w, r, segments[1])
+	case segments[0] == "groups" && len(segments) == 1:
+		f.handleGroups(w, r)
+	case segments[0] == "groups" && len(segments) == 2:
+		f.handleGroup(w, r, segments[1])
+	case segments[0] == "groups" && len(segments) == 3 && segments[2] == "members":
+		f.handleGroupMembers(w, r, segments[1])
+	case segments[0] == "groups" && len(segments) == 4 && segments[2] == "members":
+		f.handleGroupMember(w, r, segments[1], segments[3])
+	case segments[0] == "projects" && len(segments) == 1:
+		f.handleProjects(w, r)
+	case segments[0] == "projects" && len(segments) == 2:
+		f.handleProject(w, r, segments[1])
+	case segments[0] == "projects" && len(segments) == 3 && segments[2] == "pipelines":
+		f.handlePipelines(w, r, segments[1])
+	case segments[0] == "projects" && len(segments) == 4 && segments[2] == "pipelines":
+		f.handlePipeline(w, r, segments[1], segments[3])
+	case segments[0] == "projects" && len(segments) == 5 && segments[2] == "pipelines" && segments[4] == "actions":
+		f.handlePipelineActions(w, r, segments[1], segments[3])
+	case segments[0] == "projects" && len(segments) == 6 && segments[2] == "pipelines" && segments[4] == "actions":
+		f.handlePipelineAction(w, r, segments[1], segments[3], segments[5])
+	case segments[0] == "projects" && len(segments) == 3 && segments[2] == "members":
+		f.handleProjectMembers(w, r, segments[1])
+	case segments[0] == "projects" && len(segments) == 4 && segments[2] == "members":
+		f.handleProjectMember(w, r, segments[1], segments[3])
+	case segments[0] == "projects" && len(segments) == 3 && segments[2] == "groups":
+		f.handleProjectGroups(w, r, segments[1])
+	case segments[0] == "projects" && len(segments) == 4 && segments[2] == "groups":
+		f.handleProjectGroup(w, r, segments[1], segments[3])
+	default:
+		writeFakeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// handleCurrentUser serves the user the token belongs to, which is outside of the workspace
+func (f *fakeBuddy) handleCurrentUser(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+fakeBuddyToken {
+		writeFakeError(w, http.StatusUnauthorized, "Invalid token")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests[r.Method]++
+	if r.Method != http.MethodGet {
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	user := buddyWorkspaceMember{
+		Url:   fmt.Sprintf("%v/user", f.server.URL),
+		Id:    f.currentUser,
+		Name:  "Token Owner",
+		Email: "token.owner@example.com",
+	}
+	if m, ok := f.members[f.currentUser]; ok {
+		user.Name = m.Name
+		user.Email = m.Email
+	}
+
+	writeFakeJSON(w, http.StatusOK, user)
+}
+
+func (f *fakeBuddy) handleVariables(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req fakeVariableRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+		return
+	}
+
+	if req.Key == "" {
+		writeFakeError(w, http.StatusBadRequest, "Key is required")
+		return
+	}
+
+	if message := validateFakeVariable(req); message != "" {
+		writeFakeError(w, http.StatusBadRequest, message)
+		return
+	}
+
+	if req.Project.Name != "" {
+		if _, ok := f.projects[req.Project.Name]; !ok {
+			writeFakeError(w, http.StatusNotFound, "Project not found")
+			return
+		}
+	}
+
+	if req.Pipeline.Id != 0 {
+		p, ok := f.pipelines[req.Pipeline.Id]
+		if !ok || p.Project != req.Project.Name {
+			writeFakeError(w, http.StatusNotFound, "Pipeline not found")
+			return
+		}
+
+		if req.Action.Id != 0 && fakeActionIndex(p, req.Action.Id) < 0 {
+			writeFakeError(w, http.StatusNotFound, "Action not found")
+			return
+		}
+	}
+
+	if req.Environment.Id != "" {
+		if e, ok := f.environments[req.Environment.Id]; !ok || e.Project != req.Project.Name {
+			writeFakeError(w, http.StatusNotFound, "Environment not found")
+			return
+		}
+	}
+
+	for _, v := range f.variables {
+		if v.Key == req.Key && v.Project == req.Project.Name && v.PipelineId == req.Pipeline.Id &&
+			v.ActionId == req.Action.Id && v.EnvironmentId == req.Environment.Id {
+			writeFakeError(w, http.StatusBadRequest, "Variable with this key already exists")
+			return
+		}
+	}
+
+	v := &fakeVariable{
+		Id:            f.newId(),
+		Key:           req.Key,
+		Value:         req.Value,
+		Type:          req.Type,
+		File:          req.buddyRequestVariableFile,
+		Settable:      req.Settable,
+		Encrypted:     req.Encrypted,
+		Description:   req.Description,
+		Project:       req.Project.Name,
+		PipelineId:    req.Pipeline.Id,
+		ActionId:      req.Action.Id,
+		EnvironmentId: req.Environment.Id,
+	}
+	f.variables[v.Id] = v
+
+	writeFakeJSON(w, http.StatusCreated, f.variableResponse(v))
+}
+
+func (f *fakeBuddy) handleVariable(w http.ResponseWriter, r *http.Request, rawId string) {
+	id, _ := strconv.Atoi(rawId)
+	v, ok := f.variables[id]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Variable not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, f.variableResponse(v))
+	case http.MethodPatch:
+		var req fakeVariableRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		if message := validateFakeVariable(req); message != "" {
+			writeFakeError(w, http.StatusBadRequest, message)
+			return
+		}
+
+		v.Key = req.Key
+		v.Value = req.Value
+		v.Type = req.Type
+		v.Settable = req.Settable
+		v.Encrypted = req.Encrypted
+		v.Description = req.Description
+		v.File = req.buddyRequestVariableFile
+
+		writeFakeJSON(w, http.StatusOK, f.variableResponse(v))
+	case http.MethodDelete:
+		delete(f.variables, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *fakeBuddy) variableResponse(v *fakeVariable) interface{} {
+	value := v.Value
+	if v.Encrypted {
+		value = fmt.Sprintf("secure!%x", sha256.Sum256([]byte(v.Value)))
+	}
+
+	resp := buddyResponseVariable{
+		buddyResponseVariableFile: fakeVariableFile(v),
+
+		Url:         fmt.Sprintf("%v/variables/%v", f.apiURL(), v.Id),
+		Id:          v.Id,
+		Key:         v.Key,
+		Value:       value,
+		SSHKey:      v.Type == "SSH_KEY",
+		Settable:    v.Settable,
+		Encrypted:   v.Encrypted,
+		Description: v.Description,
+	}
+
+	if v.Project == "" {
+		return buddyResponseWorkspaceVariable{resp}
+	}
+
+	if v.EnvironmentId != "" {
+		environment := f.environments[v.EnvironmentId]
+		return buddyResponseEnvironmentVariable{
+			buddyResponseVariable: resp,
+
+			Project: f.projectResponse(f.projects[v.Project]),
+			Environment: buddyEnvironment{
+				URL:     fmt.Sprintf("%v/projects/%v/environments/%v", f.apiURL(), environment.Project, environment.Id),
+				HTMLURL: fmt.Sprintf("%v/%v/environments/%v", f.htmlURL(), environment.Project, environment.Id),
+				Id:      environment.Id,
+				Name:    environment.Name,
+			},
+		}
+	}
+
+	if v.ActionId != 0 {
+		pipeline := f.pipelines[v.PipelineId]
+		action := pipeline.Actions[fakeActionIndex(pipeline, v.ActionId)]
+		return buddyResponseActionVariable{
+			buddyResponseVariable: resp,
+
+			Project:  f.projectResponse(f.projects[v.Project]),
+			Pipeline: f.pipelineSummary(pipeline),
+			Action: buddyAction{
+				URL:     fmt.Sprintf("%v/projects/%v/pipelines/%v/actions/%v", f.apiURL(), pipeline.Project, pipeline.Id, v.ActionId),
+				HTMLURL: fmt.Sprintf("%v/%v/pipelines/pipeline/%v/action/%v/edit", f.htmlURL(), pipeline.Project, pipeline.Id, v.ActionId),
+				Id:      v.ActionId,
+				Name:    fmt.Sprint(action["name"]),
+				Type:    fmt.Sprint(action["type"]),
+			},
+		}
+	}
+
+	if v.PipelineId != 0 {
+		pipeline := f.pipelines[v.PipelineId]
+		return buddyResponsePipelineVariable{
+			buddyResponseVariable: resp,
+
+			Project:  f.projectResponse(f.projects[v.Project]),
+			Pipeline: f.pipelineSummary(pipeline),
+		}
+	}
+
+	return buddyResponseProjectVariable{
+		buddyResponseVariable: resp,
+
+		Project: f.projectResponse(f.projects[v.Project]),
+	}
+}
+
+// validateFakeVariable mimics the checks done by Buddy on the file fields
+func validateFakeVariable(req fakeVariableRequest) string {
+	switch req.Type {
+	case "", "VAR":
+		if req.FilePlace != "" || req.FilePath != "" || req.Passphrase != "" {
+			return "File fields are allowed only for FILE and SSH_KEY variables"
+		}
+	case "FILE", "SSH_KEY":
+		if req.FilePlace != "CONTAINER" && req.FilePlace != "NONE" {
+			return "Invalid file place"
+		}
+		if req.FilePlace == "CONTAINER" && req.FilePath == "" {
+			return "File path is required"
+		}
+		if req.Type == "FILE" && req.Passphrase != "" {
+			return "Passphrase is allowed only for SSH_KEY variables"
+		}
+	default:
+		return "Invalid variable type"
+	}
+	return ""
+}
+
+func fakeVariableFile(v *fakeVariable) buddyResponseVariableFile {
+	file := buddyResponseVariableFile{
+		Type:      v.Type,
+		FilePlace: v.File.FilePlace,
+		FilePath:  v.File.FilePath,
+		FileChmod: v.File.FileChmod,
+	}
+
+	if v.Type != "FILE" && v.Type != "SSH_KEY" {
+		return file
+	}
+
+	sum := sha256.Sum256([]byte(v.Value))
+	if file.FileChmod == "" {
+		file.FileChmod = "600"
+	}
+	file.Checksum = fmt.Sprintf("%x", sum)
+	if v.Type == "SSH_KEY" {
+		file.PublicValue = fmt.Sprintf("ssh-rsa %x", sum[:16])
+		file.KeyFingerprint = fmt.Sprintf("SHA256:%x", sum[16:])
+	}
+
+	return file
+}
+
+func (f *fakeBuddy) pipelineSummary(p *fakePipeline) buddyPipeline {
+	return buddyPipeline{
+		URL:     fmt.Sprintf("%v/projects/%v/pipelines/%v", f.apiURL(), p.Project, p.Id),
+		HTMLURL: fmt.Sprintf("%v/%v/pipelines/pipeline/%v", f.htmlURL(), p.Project, p.Id),
+		Id:      p.Id,
+		Name:    p.Pipeline.Name,
+	}
+}
+
+func (f *fakeBuddy) projectResponse(p *fakeProject) buddyProject {
+	return buddyProject{
+		URL:         fmt.Sprintf("%v/projects/%v", f.apiURL(), p.Name),
+		HTMLURL:     fmt.Sprintf("%v/%v", f.htmlURL(), p.Name),
+		Name:        p.Name,
+		DisplayName: p.DisplayName,
+		Status:      p.Status,
+	}
+}
+
+func (f *fakeBuddy) handlePermissionSets(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		permissionSets := make([]buddyPermissionSet, 0, len(f.permissionSets))
+		for _, ps := range f.permissionSets {
+			permissionSets = append(permissionSets, *ps)
+		}
+		sort.Slice(permissionSets, func(i, j int) bool {
+			return permissionSets[i].Id < permissionSets[j].Id
+		})
+
+		writeFakeJSON(w, http.StatusOK, buddyResponseListPermissionSet{
+			Url:            fmt.Sprintf("%v/permissions", f.apiURL()),
+			HTMLURL:        fmt.Sprintf("%v/permissions", f.htmlURL()),
+			PermissionSets: permissionSets,
+		})
+	case http.MethodPost:
+		var req buddyRequestCustomPermissionSet
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		if req.Name == "" || req.PipelineAccessLevel == "" || req.RepositoryAccessLevel == "" || req.SandboxAccessLevel == "" {
+			writeFakeError(w, http.StatusBadRequest, "Name and access levels are required")
+			return
+		}
+
+		id := f.newId()
+		ps := &buddyPermissionSet{
+			URL:     fmt.Sprintf("%v/permissions/%v", f.apiURL(), id),
+			HTMLURL: fmt.Sprintf("%v/permissions/%v", f.htmlURL(), id),
+			Id:      id,
+			Type:    "CUSTOM",
+		}
+		applyFakePermissionSet(ps, req)
+		f.permissionSets[id] = ps
+
+		writeFakeJSON(w, http.StatusCreated, ps)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *fakeBuddy) handlePermissionSet(w http.ResponseWriter, r *http.Request, rawId string) {
+	id, _ := strconv.Atoi(rawId)
+	ps, ok := f.permissionSets[id]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Permission set not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, ps)
+	case http.MethodPatch:
+		if ps.Type != "CUSTOM" {
+			writeFakeError(w, http.StatusBadRequest, "Only custom permission sets can be changed")
+			return
+		}
+
+		var req buddyRequestCustomPermissionSet
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		applyFakePermissionSet(ps, req)
+		writeFakeJSON(w, http.StatusOK, ps)
+	case http.MethodDelete:
+		if ps.Type != "CUSTOM" {
+			writeFakeError(w, http.StatusBadRequest, "Only custom permission sets can be deleted")
+			return
+		}
+
+		delete(f.permissionSets, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// applyFakePermissionSet copies the fields set in the request to the permission set
+func applyFakePermissionSet(ps *buddyPermissionSet, req buddyRequestCustomPermissionSet) {
+	if req.Name != "" {
+		ps.Name = req.Name
+	}
+	ps.Description = req.Description
+	if req.PipelineAccessLevel != "" {
+		ps.PipelineAccessLevel = req.PipelineAccessLevel
+	}
+	if req.RepositoryAccessLevel != "" {
+		ps.RepositoryAccessLevel = req.RepositoryAccessLevel
+	}
+	if req.SandboxAccessLevel != "" {
+		ps.SandboxAccessLevel = req.SandboxAccessLevel
+	}
+}
+
+func (f *fakeBuddy) groupResponse(g *fakeGroup) buddyResponseGroup {
+	return buddyResponseGroup{
+		Url:                       fmt.Sprintf("%v/groups/%v", f.apiURL(), g.Id),
+		HTMLURL:                   fmt.Sprintf("%v/groups/%v", f.htmlURL(), g.Id),
+		Id:                        g.Id,
+		Name:                      g.Group.Name,
+		Description:               g.Group.Description,
+		AutoAssignToNewProjects:   g.Group.AutoAssignToNewProjects,
+		AutoAssignPermissionSetId: g.Group.AutoAssignPermissionSetId,
+	}
+}
+
+// validateFakeGroup mimics the validation Buddy does on group requests
+func (f *fakeBuddy) validateFakeGroup(group buddyRequestGroup) string {
+	if group.Name == "" {
+		return "Name is required"
+	}
+
+	if group.AutoAssignToNewProjects {
+		if _, ok := f.permissionSets[group.AutoAssignPermissionSetId]; !ok {
+			return "Permission set not found"
+		}
+	}
+
+	return ""
+}
+
+func (f *fakeBuddy) handleGroups(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req buddyRequestGroup
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+		return
+	}
+
+	if msg := f.validateFakeGroup(req); msg != "" {
+		writeFakeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	group := &fakeGroup{Id: f.newId(), Group: req, Members: map[int]bool{}}
+	f.groups[group.Id] = group
+
+	writeFakeJSON(w, http.StatusCreated, f.groupResponse(group))
+}
+
+func (f *fakeBuddy) handleGroup(w http.ResponseWriter, r *http.Request, rawId string) {
+	id, _ := strconv.Atoi(rawId)
+	group, ok := f.groups[id]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Group not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, f.groupResponse(group))
+	case http.MethodPatch:
+		// Fields missing from the request keep their value
+		req := group.Group
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		if msg := f.validateFakeGroup(req); msg != "" {
+			writeFakeError(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		group.Group = req
+		writeFakeJSON(w, http.StatusOK, f.groupResponse(group))
+	case http.MethodDelete:
+		delete(f.groups, id)
+		for _, p := range f.projects {
+			delete(p.Groups, id)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *fakeBuddy) handleGroupMembers(w http.ResponseWriter, r *http.Request, rawGroupId string) {
+	groupId, _ := strconv.Atoi(rawGroupId)
+	group, ok := f.groups[groupId]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Group not found")
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req buddyRequestGroupMember
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+		return
+	}
+
+	memberId, _ := strconv.Atoi(req.Id)
+	member, ok := f.members[memberId]
+	if !ok {
+		writeFakeError(w, http.StatusBadRequest, "Member not found in the workspace")
+		return
+	}
+
+	if group.Members[memberId] {
+		writeFakeError(w, http.StatusBadRequest, "Member is already in the group")
+		return
+	}
+
+	group.Members[memberId] = true
+	writeFakeJSON(w, http.StatusCreated, member)
+}
+
+func (f *fakeBuddy) handleGroupMember(w http.ResponseWriter, r *http.Request, rawGroupId string, rawMemberId string) {
+	groupId, _ := strconv.Atoi(rawGroupId)
+	group, ok := f.groups[groupId]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Group not found")
+		return
+	}
+
+	memberId, _ := strconv.Atoi(rawMemberId)
+	if !group.Members[memberId] {
+		writeFakeError(w, http.StatusNotFound, "Member not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, f.members[memberId])
+	case http.MethodDelete:
+		delete(group.Members, memberId)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *fakeBuddy) handleProjects(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		f.listProjects(w, r)
+		return
+	case http.MethodPost:
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req buddyRequestCreateProject
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+		return
+	}
+
+	if req.DisplayName == "" {
+		writeFakeError(w, http.StatusBadRequest, "Display name is required")
+		return
+	}
+
+	name := req.Name
+	if name == "" {
+		name = strings.ToLower(strings.ReplaceAll(req.DisplayName, " ", "-"))
+	}
+
+	if _, ok := f.projects[name]; ok {
+		writeFakeError(w, http.StatusBadRequest, "Project with this name already exists")
+		return
+	}
+
+	project := f.createProject(name, req.DisplayName)
+	writeFakeJSON(w, http.StatusCreated, f.projectDetailsResponse(project))
+}
+
+func (f *fakeBuddy) listProjects(w http.ResponseWriter, r *http.Request) {
+	page, perPage := fakePagination(r)
+	status := r.URL.Query().Get("status")
+
+	projects := make([]buddyProject, 0, len(f.projects))
+	for _, p := range f.projects {
+		if status != "" && p.Status != status {
+			continue
+		}
+		projects = append(projects, f.projectResponse(p))
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	start, end := fakePageBounds(len(projects), page, perPage)
+	writeFakeJSON(w, http.StatusOK, buddyResponseListProject{
+		Url:      fmt.Sprintf("%v/projects", f.apiURL()),
+		HTMLURL:  f.htmlURL(),
+		Projects: projects[start:end],
+	})
+}
+
+func (f *fakeBuddy) handleProject(w http.ResponseWriter, r *http.Request, projectName string) {
+	project, ok := f.projects[projectName]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, f.projectDetailsResponse(project))
+	case http.MethodPatch:
+		var req buddyRequestUpdateProject
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		if req.Status != "" && req.Status != "ACTIVE" && req.Status != "CLOSED" {
+			writeFakeError(w, http.StatusBadRequest, "Invalid project status")
+			return
+		}
+
+		if req.Name != "" && req.Name != project.Name {
+			if _, ok := f.projects[req.Name]; ok {
+				writeFakeError(w, http.StatusBadRequest, "Project with this name already exists")
+				return
+			}
+
+			delete(f.projects, project.Name)
+			for _, v := range f.variables {
+				if v.Project == project.Name {
+					v.Project = req.Name
+				}
+			}
+			for _, p := range f.pipelines {
+				if p.Project == project.Name {
+					p.Project = req.Name
+				}
+			}
+			project.Name = req.Name
+			f.projects[project.Name] = project
+		}
+
+		if req.DisplayName != "" {
+			project.DisplayName = req.DisplayName
+		}
+
+		if req.Status != "" {
+			project.Status = req.Status
+		}
+
+		writeFakeJSON(w, http.StatusOK, f.projectDetailsResponse(project))
+	case http.MethodDelete:
+		f.deleteProject(project.Name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *fakeBuddy) projectDetailsResponse(p *fakeProject) buddyResponseProject {
+	return buddyResponseProject{
+		URL:            fmt.Sprintf("%v/projects/%v", f.apiURL(), p.Name),
+		HTMLURL:        fmt.Sprintf("%v/%v", f.htmlURL(), p.Name),
+		Name:           p.Name,
+		DisplayName:    p.DisplayName,
+		Status:         p.Status,
+		CreateDate:     p.CreateDate,
+		HttpRepository: fmt.Sprintf("%v/%v/%v", f.htmlURL(), p.Name, p.Name),
+		SshRepository:  fmt.Sprintf("git@buddy.example.com:%v/%v", fakeBuddyWorkspace, p.Name),
+		DefaultBranch:  "master",
+	}
+}
+
+func (f *fakeBuddy) handlePipelines(w http.ResponseWriter, r *http.Request, projectName string) {
+	if _, ok := f.projects[projectName]; !ok {
+		writeFakeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req buddyRequestPipeline
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+		return
+	}
+
+	if message := validateFakePipeline(req); message != "" {
+		writeFakeError(w, http.StatusBadRequest, message)
+		return
+	}
+
+	for _, p := range f.pipelines {
+		if p.Project == projectName && p.Pipeline.Name == req.Name {
+			writeFakeError(w, http.StatusBadRequest, "Pipeline with this name already exists")
+			return
+		}
+	}
+
+	pipeline := &fakePipeline{Id: f.newId(), Project: projectName, Pipeline: req}
+	f.pipelines[pipeline.Id] = pipeline
+
+	writeFakeJSON(w, http.StatusCreated, f.pipelineResponse(pipeline))
+}
+
+func (f *fakeBuddy) handlePipeline(w http.ResponseWriter, r *http.Request, projectName string, rawId string) {
+	id, _ := strconv.Atoi(rawId)
+	pipeline, ok := f.pipelines[id]
+	if !ok || pipeline.Project != projectName {
+		writeFakeError(w, http.StatusNotFound, "Pipeline not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, f.pipelineResponse(pipeline))
+	case http.MethodPatch:
+		// Like Buddy, fields missing from the body keep their current value
+		var fields map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		req, err := mergeFakePipeline(pipeline.Pipeline, fields)
+		if err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		if message := validateFakePipeline(req); message != "" {
+			writeFakeError(w, http.StatusBadRequest, message)
+			return
+		}
+
+		pipeline.Pipeline = req
+		writeFakeJSON(w, http.StatusOK, f.pipelineResponse(pipeline))
+	case http.MethodDelete:
+		f.deletePipeline(id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *fakeBuddy) pipeline(projectName string, rawId string) *fakePipeline {
+	id, _ := strconv.Atoi(rawId)
+	pipeline, ok := f.pipelines[id]
+	if !ok || pipeline.Project != projectName {
+		return nil
+	}
+	return pipeline
+}
+
+func (f *fakeBuddy) handlePipelineActions(w http.ResponseWriter, r *http.Request, projectName string, rawPipelineId string) {
+	pipeline := f.pipeline(projectName, rawPipelineId)
+	if pipeline == nil {
+		writeFakeError(w, http.StatusNotFound, "Pipeline not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		actions := make([]map[string]interface{}, 0, len(pipeline.Actions))
+		for _, action := range pipeline.Actions {
+			actions = append(actions, f.pipelineActionResponse(pipeline, action))
+		}
+
+		writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+			"url":     fmt.Sprintf("%v/projects/%v/pipelines/%v/actions", f.apiURL(), projectName, pipeline.Id),
+			"actions": actions,
+		})
+	case http.MethodPost:
+		var req map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		if req["name"] == nil || req["name"] == "" || req["type"] == nil || req["type"] == "" {
+			writeFakeError(w, http.StatusBadRequest, "Name and type are required")
+			return
+		}
+
+		action := map[string]interface{}{
+			"trigger_time":              "ON_EVERY_EXECUTION",
+			"run_only_on_first_failure": false,
+			"variables":                 []interface{}{},
+		}
+		if req["type"] == "BUILD" {
+			action["shell"] = "BASH"
+		}
+
+		afterActionId, _ := req["after_action_id"].(float64)
+		delete(req, "after_action_id")
+		for key, value := range req {
+			action[key] = value
+		}
+		action["id"] = f.newId()
+
+		if !fakeInsertAction(pipeline, action, int(afterActionId)) {
+			writeFakeError(w, http.StatusBadRequest, "Action to be placed after not found")
+			return
+		}
+
+		writeFakeJSON(w, http.StatusCreated, f.pipelineActionResponse(pipeline, action))
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *fakeBuddy) handlePipelineAction(w http.ResponseWriter, r *http.Request, projectName string, rawPipelineId string, rawActionId string) {
+	pipeline := f.pipeline(projectName, rawPipelineId)
+	if pipeline == nil {
+		writeFakeError(w, http.StatusNotFound, "Pipeline not found")
+		return
+	}
+
+	actionId, _ := strconv.Atoi(rawActionId)
+	index := fakeActionIndex(pipeline, actionId)
+	if index < 0 {
+		writeFakeError(w, http.StatusNotFound, "Action not found")
+		return
+	}
+	action := pipeline.Actions[index]
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, f.pipelineActionResponse(pipeline, action))
+	case http.MethodPatch:
+		var req map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		delete(req, "id")
+		delete(req, "after_action_id")
+		for key, value := range req {
+			action[key] = value
+		}
+
+		writeFakeJSON(w, http.StatusOK, f.pipelineActionResponse(pipeline, action))
+	case http.MethodDelete:
+		pipeline.Actions = append(pipeline.Actions[:index], pipeline.Actions[index+1:]...)
+		for id, v := range f.variables {
+			if v.ActionId == actionId {
+				delete(f.variables, id)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func fakeActionIndex(pipeline *fakePipeline, actionId int) int {
+	for i, action := range pipeline.Actions {
+		if action["id"] == actionId {
+			return i
+		}
+	}
+	return -1
+}
+
+// fakeInsertAction places the action after the given one, or at the end of the
+// pipeline when afterActionId is zero
+func fakeInsertAction(pipeline *fakePipeline, action map[string]interface{}, afterActionId int) bool {
+	if afterActionId == 0 {
+		pipeline.Actions = append(pipeline.Actions, action)
+		return true
+	}
+
+	for i, a := range pipeline.Actions {
+		if a["id"] == afterActionId {
+			actions := append([]map[string]interface{}{}, pipeline.Actions[:i+1]...)
+			actions = append(actions, action)
+			pipeline.Actions = append(actions, pipeline.Actions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeBuddy) pipelineActionResponse(pipeline *fakePipeline, action map[string]interface{}) map[string]interface{} {
+	resp := map[string]interface{}{
+		"url":      fmt.Sprintf("%v/projects/%v/pipelines/%v/actions/%v", f.apiURL(), pipeline.Project, pipeline.Id, action["id"]),
+		"html_url": fmt.Sprintf("%v/%v/pipelines/pipeline/%v/action/%v/edit", f.htmlURL(), pipeline.Project, pipeline.Id, action["id"]),
+	}
+	for key, value := range action {
+		resp[key] = value
+	}
+	return resp
+}
+
+func (f *fakeBuddy) pipelineActionIds(pipelineId int) []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	pipeline, ok := f.pipelines[pipelineId]
+	if !ok {
+		return nil
+	}
+
+	ids := make([]int, 0, len(pipeline.Actions))
+	for _, action := range pipeline.Actions {
+		ids = append(ids, action["id"].(int))
+	}
+	return ids
+}
+
+// mergeFakePipeline applies the fields of a PATCH body to the pipeline
+func mergeFakePipeline(current buddyRequestPipeline, fields map[string]json.RawMessage) (buddyRequestPipeline, error) {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return current, err
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return current, err
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+
+	data, err = json.Marshal(merged)
+	if err != nil {
+		return current, err
+	}
+
+	var req buddyRequestPipeline
+	err = json.Unmarshal(data, &req)
+	return req, err
+}
+
+func validateFakePipeline(req buddyRequestPipeline) string {
+	switch {
+	case req.Name == "":
+		return "Name is required"
+	case req.On != "CLICK" && req.On != "EVENT" && req.On != "SCHEDULE":
+		return "Invalid trigger mode"
+	case req.On == "EVENT" && len(req.Events) == 0:
+		return "Events are required for pipeline triggered on events"
+	case req.On == "SCHEDULE" && req.Cron == "" && req.Delay == 0:
+		return "Cron or delay is required for scheduled pipeline"
+	}
+	return ""
+}
+
+func (f *fakeBuddy) pipelineResponse(p *fakePipeline) buddyResponsePipeline {
+	req := p.Pipeline
+
+	priority := req.Priority
+	if priority == "" {
+		priority = "NORMAL"
+	}
+
+	template := req.ExecutionMessageTemplate
+	if template == "" {
+		template = "$BUDDY_EXECUTION_REVISION_SUBJECT"
+	}
+
+	return buddyResponsePipeline{
+		Url:                       fmt.Sprintf("%v/projects/%v/pipelines/%v", f.apiURL(), p.Project, p.Id),
+		HTMLURL:                   fmt.Sprintf("%v/%v/pipelines/pipeline/%v", f.htmlURL(), p.Project, p.Id),
+		Id:                        p.Id,
+		Name:                      req.Name,
+		On:                        req.On,
+		Refs:                      req.Refs,
+		Events:                    req.Events,
+		TriggerConditions:         req.TriggerConditions,
+		Cron:                      req.Cron,
+		Delay:                     req.Delay,
+		StartDate:                 req.StartDate,
+		Priority:                  priority,
+		ExecutionMessageTemplate:  template,
+		TargetSiteUrl:             req.TargetSiteUrl,
+		AlwaysFromScratch:         req.AlwaysFromScratch,
+		FailOnPrepareEnvWarning:   req.FailOnPrepareEnvWarning,
+		AutoClearCache:            req.AutoClearCache,
+		NoSkipToMostRecent:        req.NoSkipToMostRecent,
+		DoNotCreateCommitStatus:   req.DoNotCreateCommitStatus,
+		IgnoreFailOnProjectStatus: req.IgnoreFailOnProjectStatus,
+		Paused:                    req.Paused,
+		LastExecutionStatus:       "INITIAL",
+		CreateDate:                "2021-07-01T10:00:00Z",
+		Project:                   f.projectResponse(f.projects[p.Project]),
+	}
+}
+
+func (f *fakeBuddy) handleMembers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		page, perPage := fakePagination(r)
+
+		members := make([]buddyWorkspaceMember, 0, len(f.members))
+		for _, m := range f.members {
+			members = append(members, buddyWorkspaceMember{
+				Url:              m.Url,
+				HTMLURL:          m.HTMLURL,
+				Id:               m.Id,
+				Name:             m.Name,
+				AvatarUrl:        m.AvatarUrl,
+				Title:            m.Title,
+				Email:            m.Email,
+				Admin:            m.Admin,
+				WorkspaceOwner:   m.WorkspaceOwner,
+				InvitationStatus: m.InvitationStatus,
+			})
+		}
+		sort.Slice(members, func(i, j int) bool {
+			return members[i].Name < members[j].Name
+		})
+
+		start, end := fakePageBounds(len(members), page, perPage)
+		writeFakeJSON(w, http.StatusOK, buddyResponseListWorkspaceMember{
+			Url:     fmt.Sprintf("%v/members", f.apiURL()),
+			HTMLURL: fmt.Sprintf("%v/people", f.htmlURL()),
+			Members: members[start:end],
+		})
+	case http.MethodPost:
+		var req struct {
+			Email string `json:"email"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+			writeFakeError(w, http.StatusBadRequest, "Email is required")
+			return
+		}
+
+		for _, m := range f.members {
+			if strings.EqualFold(m.Email, req.Email) {
+				writeFakeError(w, http.StatusBadRequest, "Member with this email already exists")
+				return
+			}
+		}
+
+		member := f.createMember(req.Email, strings.Split(req.Email, "@")[0])
+		member.InvitationStatus = "PENDING"
+		f.invitations[member.Id]++
+		writeFakeJSON(w, http.StatusCreated, member)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *fakeBuddy) handleMember(w http.ResponseWriter, r *http.Request, rawId string) {
+	id, _ := strconv.Atoi(rawId)
+	member, ok := f.members[id]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Member not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, member)
+	case http.MethodPatch:
+		var req struct {
+			Admin *bool `json:"admin"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		if req.Admin != nil {
+			member.Admin = *req.Admin
+		}
+		writeFakeJSON(w, http.StatusOK, member)
+	case http.MethodDelete:
+		delete(f.members, id)
+		for _, p := range f.projects {
+			delete(p.Members, id)
+		}
+		for _, g := range f.groups {
+			delete(g.Members, id)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *fakeBuddy) handleResendInvitation(w http.ResponseWriter, r *http.Request, rawId string) {
+	id, _ := strconv.Atoi(rawId)
+	member, ok := f.members[id]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Member not found")
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if member.InvitationStatus != "PENDING" {
+		writeFakeError(w, http.StatusBadRequest, "Member already accepted the invitation")
+		return
+	}
+
+	f.invitations[id]++
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeBuddy) handleProjectMembers(w http.ResponseWriter, r *http.Request, projectName string) {
+	project, ok := f.projects[projectName]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		f.listProjectMembers(w, r, project)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req buddyRequestProjectMember
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+		return
+	}
+
+	memberId, _ := strconv.Atoi(req.Id)
+	if _, ok := f.members[memberId]; !ok {
+		writeFakeError(w, http.StatusBadRequest, "Member not found in the workspace")
+		return
+	}
+
+	if _, ok := f.permissionSets[req.PermissionSet.Id]; !ok {
+		writeFakeError(w, http.StatusBadRequest, "Permission set not found")
+		return
+	}
+
+	if _, ok := project.Members[memberId]; ok {
+		writeFakeError(w, http.StatusBadRequest, "Member is already assigned to the project")
+		return
+	}
+
+	pm := &fakeProjectMember{MemberId: memberId, PermissionSetId: req.PermissionSet.Id}
+	project.Members[memberId] = pm
+
+	writeFakeJSON(w, http.StatusCreated, f.projectMemberResponse(pm))
+}
+
+// listProjectMembers mimics Buddy by leaving the permission set out of the list
+func (f *fakeBuddy) listProjectMembers(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	page, perPage := fakePagination(r)
+
+	members := make([]buddyWorkspaceMember, 0, len(project.Members))
+	for _, pm := range project.Members {
+		m := f.projectMemberResponse(pm)
+		members = append(members, buddyWorkspaceMember{
+			Url:            m.Url,
+			HTMLURL:        m.HTMLURL,
+			Id:             m.Id,
+			Name:           m.Name,
+			AvatarUrl:      m.AvatarUrl,
+			Title:          m.Title,
+			Email:          m.Email,
+			Admin:          m.Admin,
+			WorkspaceOwner: m.WorkspaceOwner,
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Id < members[j].Id
+	})
+
+	start, end := fakePageBounds(len(members), page, perPage)
+	writeFakeJSON(w, http.StatusOK, buddyResponseListProjectMember{
+		Url:     fmt.Sprintf("%v/projects/%v/members", f.apiURL(), project.Name),
+		HTMLURL: fmt.Sprintf("%v/%v/people", f.htmlURL(), project.Name),
+		Members: members[start:end],
+	})
+}
+
+func (f *fakeBuddy) handleProjectMember(w http.ResponseWriter, r *http.Request, projectName string, rawMemberId string) {
+	project, ok := f.projects[projectName]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	memberId, _ := strconv.Atoi(rawMemberId)
+	pm, ok := project.Members[memberId]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Member not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, f.projectMemberResponse(pm))
+	case http.MethodPatch:
+		var req buddyRequestPermissionSet
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		if _, ok := f.permissionSets[req.PermissionSet.Id]; !ok {
+			writeFakeError(w, http.StatusBadRequest, "Permission set not found")
+			return
+		}
+
+		pm.PermissionSetId = req.PermissionSet.Id
+		writeFakeJSON(w, http.StatusOK, f.projectMemberResponse(pm))
+	case http.MethodDelete:
+		delete(project.Members, memberId)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *fakeBuddy) projectGroupResponse(groupId int, permissionSetId int) buddyResponseProjectGroup {
+	g := f.groupResponse(f.groups[groupId])
+	return buddyResponseProjectGroup{
+		Url:           g.Url,
+		HTMLURL:       g.HTMLURL,
+		Id:            g.Id,
+		Name:          g.Name,
+		Description:   g.Description,
+		PermissionSet: *f.permissionSets[permissionSetId],
+	}
+}
+
+func (f *fakeBuddy) handleProjectGroups(w http.ResponseWriter, r *http.Request, projectName string) {
+	project, ok := f.projects[projectName]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req buddyRequestProjectGroup
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+		return
+	}
+
+	groupId, _ := strconv.Atoi(req.Id)
+	if _, ok := f.groups[groupId]; !ok {
+		writeFakeError(w, http.StatusBadRequest, "Group not found in the workspace")
+		return
+	}
+
+	if _, ok := f.permissionSets[req.PermissionSet.Id]; !ok {
+		writeFakeError(w, http.StatusBadRequest, "Permission set not found")
+		return
+	}
+
+	if _, ok := project.Groups[groupId]; ok {
+		writeFakeError(w, http.StatusBadRequest, "Group is already assigned to the project")
+		return
+	}
+
+	project.Groups[groupId] = req.PermissionSet.Id
+	writeFakeJSON(w, http.StatusCreated, f.projectGroupResponse(groupId, req.PermissionSet.Id))
+}
+
+func (f *fakeBuddy) handleProjectGroup(w http.ResponseWriter, r *http.Request, projectName string, rawGroupId string) {
+	project, ok := f.projects[projectName]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	groupId, _ := strconv.Atoi(rawGroupId)
+	permissionSetId, ok := project.Groups[groupId]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Group not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, f.projectGroupResponse(groupId, permissionSetId))
+	case http.MethodPatch:
+		var req buddyRequestPermissionSet
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Malformed request body")
+			return
+		}
+
+		if _, ok := f.permissionSets[req.PermissionSet.Id]; !ok {
+			writeFakeError(w, http.StatusBadRequest, "Permission set not found")
+			return
+		}
+
+		project.Groups[groupId] = req.PermissionSet.Id
+		writeFakeJSON(w, http.StatusOK, f.projectGroupResponse(groupId, req.PermissionSet.Id))
+	case http.MethodDelete:
+		delete(project.Groups, groupId)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (f *fakeBuddy) projectMemberResponse(pm *fakeProjectMember) buddyResponseProjectMember {
+	m := f.members[pm.MemberId]
+	return buddyResponseProjectMember{
+		Url:            m.Url,
+		HTMLURL:        m.HTMLURL,
+		Id:             m.Id,
+		Name:           m.Name,
+		AvatarUrl:      m.AvatarUrl,
+		Title:          m.Title,
+		Email:          m.Email,
+		Admin:          m.Admin,
+		WorkspaceOwner: m.WorkspaceOwner,
+		PermissionSet:  *f.permissionSets[pm.PermissionSetId],
+	}
+}
+
+func fakePagination(r *http.Request) (int, int) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = 20
+	}
+
+	return page, perPage
+}
+
+// fakePageBounds returns the slice bounds of the requested page
+func fakePageBounds(total int, page int, perPage int) (int, int) {
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeFakeError(w http.ResponseWriter, status int, message string) {
+	writeFakeJSON(w, status, map[string]interface{}{
+		"errors": []map[string]string{
+			{"message": message},
+		},
+	})
+}
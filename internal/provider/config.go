@@ -1,13 +1,17 @@
 package provider
 
 import (
+	"context"
 	"net/http"
 )
 
 type Config struct {
-	BuddyURL  string
-	Token     string
-	VerifySSL bool
+	BuddyURL          string
+	Token             string
+	VerifySSL         bool
+	MaxRetries        int
+	RetryMaxWait      int
+	RequestsPerSecond float64
 }
 
 type buddyAdapter struct {
@@ -24,18 +28,43 @@ type buddyProject struct {
 	Status      string `json:"status"`
 }
 
+type buddyPipeline struct {
+	URL     string `json:"url"`
+	HTMLURL string `json:"html_url"`
+	Id      int    `json:"id"`
+	Name    string `json:"name"`
+}
+
+type buddyAction struct {
+	URL     string `json:"url"`
+	HTMLURL string `json:"html_url"`
+	Id      int    `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+}
+
+type buddyEnvironment struct {
+	URL     string `json:"url"`
+	HTMLURL string `json:"html_url"`
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+}
+
 type buddyId struct {
 	Id int `json:"id"`
 }
 
 type buddyWorkspaceMember struct {
-	Url       string `json:"url"`
-	HTMLURL   string `json:"html_url"`
-	Id        int    `json:"id"`
-	Name      string `json:"name"`
-	AvatarUrl string `json:"avatar_url"`
-	Title     string `json:"title"`
-	Email     string `json:"email"`
+	Url              string `json:"url"`
+	HTMLURL          string `json:"html_url"`
+	Id               int    `json:"id"`
+	Name             string `json:"name"`
+	AvatarUrl        string `json:"avatar_url"`
+	Title            string `json:"title"`
+	Email            string `json:"email"`
+	Admin            bool   `json:"admin"`
+	WorkspaceOwner   bool   `json:"workspace_owner"`
+	InvitationStatus string `json:"invitation_status"`
 }
 
 type buddyPermissionSet struct {
@@ -47,9 +76,24 @@ type buddyPermissionSet struct {
 	Type                  string `json:"type"`
 	RepositoryAccessLevel string `json:"repository_access_level"`
 	PipelineAccessLevel   string `json:"pipeline_access_level"`
+	SandboxAccessLevel    string `json:"sandbox_access_level"`
 }
 
-type buddyResponseWorkspaceVariable struct {
+// buddyResponseVariableFile holds the fields of FILE and SSH_KEY variables
+type buddyResponseVariableFile struct {
+	Type           string `json:"type"`
+	FilePlace      string `json:"file_place"`
+	FilePath       string `json:"file_path"`
+	FileChmod      string `json:"file_chmod"`
+	PublicValue    string `json:"public_value"`
+	KeyFingerprint string `json:"key_fingerprint"`
+	Checksum       string `json:"checksum"`
+}
+
+// buddyResponseVariable holds the fields shared by the variables of every scope
+type buddyResponseVariable struct {
+	buddyResponseVariableFile
+
 	Url         string `json:"url"`
 	Id          int    `json:"id"`
 	Key         string `json:"key"`
@@ -60,28 +104,49 @@ type buddyResponseWorkspaceVariable struct {
 	Description string `json:"description"`
 }
 
+type buddyResponseWorkspaceVariable struct {
+	buddyResponseVariable
+}
+
 type buddyResponseProjectVariable struct {
-	Url         string       `json:"url"`
-	Id          int          `json:"id"`
-	Key         string       `json:"key"`
-	Value       string       `json:"value"`
-	SSHKey      bool         `json:"ssh_key"`
-	Settable    bool         `json:"settable"`
-	Encrypted   bool         `json:"encrypted"`
-	Description string       `json:"description"`
-	Project     buddyProject `json:"project"`
+	buddyResponseVariable
+
+	Project buddyProject `json:"project"`
+}
+
+type buddyResponsePipelineVariable struct {
+	buddyResponseVariable
+
+	Project  buddyProject  `json:"project"`
+	Pipeline buddyPipeline `json:"pipeline"`
+}
+
+type buddyResponseActionVariable struct {
+	buddyResponseVariable
+
+	Project  buddyProject  `json:"project"`
+	Pipeline buddyPipeline `json:"pipeline"`
+	Action   buddyAction   `json:"action"`
+}
+
+type buddyResponseEnvironmentVariable struct {
+	buddyResponseVariable
+
+	Project     buddyProject     `json:"project"`
+	Environment buddyEnvironment `json:"environment"`
 }
 
 type buddyResponseWorkspaceMember struct {
-	Url            string `json:"url"`
-	HTMLURL        string `json:"html_url"`
-	Id             int    `json:"id"`
-	Name           string `json:"name"`
-	AvatarUrl      string `json:"avatar_url"`
-	Title          string `json:"title"`
-	Email          string `json:"email"`
-	Admin          bool   `json:"admin"`
-	WorkspaceOwner bool   `json:"workspace_owner"`
+	Url              string `json:"url"`
+	HTMLURL          string `json:"html_url"`
+	Id               int    `json:"id"`
+	Name             string `json:"name"`
+	AvatarUrl        string `json:"avatar_url"`
+	Title            string `json:"title"`
+	Email            string `json:"email"`
+	Admin            bool   `json:"admin"`
+	WorkspaceOwner   bool   `json:"workspace_owner"`
+	InvitationStatus string `json:"invitation_status"`
 }
 
 type buddyResponseProjectMember struct {
@@ -97,13 +162,148 @@ type buddyResponseProjectMember struct {
 	PermissionSet  buddyPermissionSet `json:"permission_set"`
 }
 
+type buddyResponseGroup struct {
+	Url                       string `json:"url"`
+	HTMLURL                   string `json:"html_url"`
+	Id                        int    `json:"id"`
+	Name                      string `json:"name"`
+	Description               string `json:"description"`
+	AutoAssignToNewProjects   bool   `json:"auto_assign_to_new_projects"`
+	AutoAssignPermissionSetId int    `json:"auto_assign_permission_set_id"`
+}
+
+type buddyResponseProjectGroup struct {
+	Url           string             `json:"url"`
+	HTMLURL       string             `json:"html_url"`
+	Id            int                `json:"id"`
+	Name          string             `json:"name"`
+	Description   string             `json:"description"`
+	PermissionSet buddyPermissionSet `json:"permission_set"`
+}
+
+type buddyResponseProject struct {
+	URL            string               `json:"url"`
+	HTMLURL        string               `json:"html_url"`
+	Name           string               `json:"name"`
+	DisplayName    string               `json:"display_name"`
+	Status         string               `json:"status"`
+	CreateDate     string               `json:"create_date"`
+	CreatedBy      buddyWorkspaceMember `json:"created_by"`
+	HttpRepository string               `json:"http_repository"`
+	SshRepository  string               `json:"ssh_repository"`
+	DefaultBranch  string               `json:"default_branch"`
+}
+
+type buddyPipelineEvent struct {
+	Type string   `json:"type"`
+	Refs []string `json:"refs"`
+}
+
+type buddyPipelineTriggerCondition struct {
+	TriggerCondition      string   `json:"trigger_condition"`
+	TriggerConditionPaths []string `json:"trigger_condition_paths,omitempty"`
+	TriggerVariableKey    string   `json:"trigger_variable_key,omitempty"`
+	TriggerVariableValue  string   `json:"trigger_variable_value,omitempty"`
+	TriggerHours          []int    `json:"trigger_hours,omitempty"`
+	TriggerDays           []int    `json:"trigger_days,omitempty"`
+	ZoneId                string   `json:"zone_id,omitempty"`
+	TriggerProjectName    string   `json:"trigger_project_name,omitempty"`
+	TriggerPipelineName   string   `json:"trigger_pipeline_name,omitempty"`
+}
+
+type buddyResponsePipeline struct {
+	Url                       string                          `json:"url"`
+	HTMLURL                   string                          `json:"html_url"`
+	Id                        int                             `json:"id"`
+	Name                      string                          `json:"name"`
+	On                        string                          `json:"on"`
+	Refs                      []string                        `json:"refs"`
+	Events                    []buddyPipelineEvent            `json:"events"`
+	TriggerConditions         []buddyPipelineTriggerCondition `json:"trigger_conditions"`
+	Cron                      string                          `json:"cron,omitempty"`
+	Delay                     int                             `json:"delay,omitempty"`
+	StartDate                 string                          `json:"start_date,omitempty"`
+	Priority                  string                          `json:"priority"`
+	ExecutionMessageTemplate  string                          `json:"execution_message_template"`
+	TargetSiteUrl             string                          `json:"target_site_url"`
+	AlwaysFromScratch         bool                            `json:"always_from_scratch"`
+	FailOnPrepareEnvWarning   bool                            `json:"fail_on_prepare_env_warning"`
+	AutoClearCache            bool                            `json:"auto_clear_cache"`
+	NoSkipToMostRecent        bool                            `json:"no_skip_to_most_recent"`
+	DoNotCreateCommitStatus   bool                            `json:"do_not_create_commit_status"`
+	IgnoreFailOnProjectStatus bool                            `json:"ignore_fail_on_project_status"`
+	Paused                    bool                            `json:"paused"`
+	LastExecutionStatus       string                          `json:"last_execution_status"`
+	CreateDate                string                          `json:"create_date"`
+	Project                   buddyProject                    `json:"project"`
+}
+
+type buddyActionVariable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type"`
+	Settable    bool   `json:"settable"`
+	Description string `json:"description"`
+}
+
+type buddyResponsePipelineAction struct {
+	Url                   string                `json:"url"`
+	HTMLURL               string                `json:"html_url"`
+	Id                    int                   `json:"id"`
+	Name                  string                `json:"name"`
+	Type                  string                `json:"type"`
+	TriggerTime           string                `json:"trigger_time"`
+	RunOnlyOnFirstFailure bool                  `json:"run_only_on_first_failure"`
+	ExecuteCommands       []string              `json:"execute_commands"`
+	DockerImageName       string                `json:"docker_image_name"`
+	DockerImageTag        string                `json:"docker_image_tag"`
+	Variables             []buddyActionVariable `json:"variables"`
+
+	// Raw holds every field returned by Buddy, including the action specific ones
+	Raw map[string]interface{} `json:"-"`
+}
+
+type buddyResponseListPipelineAction struct {
+	Url     string                        `json:"url"`
+	HTMLURL string                        `json:"html_url"`
+	Actions []buddyResponsePipelineAction `json:"actions"`
+}
+
 type buddyResponseListWorkspaceMember struct {
 	Url     string                 `json:"url"`
 	HTMLURL string                 `json:"html_url"`
 	Members []buddyWorkspaceMember `json:"members"`
 }
 
+type buddyResponseListProjectMember struct {
+	Url     string                 `json:"url"`
+	HTMLURL string                 `json:"html_url"`
+	Members []buddyWorkspaceMember `json:"members"`
+}
+
+type buddyResponseListPermissionSet struct {
+	Url            string               `json:"url"`
+	HTMLURL        string               `json:"html_url"`
+	PermissionSets []buddyPermissionSet `json:"permission_sets"`
+}
+
+type buddyResponseListProject struct {
+	Url      string         `json:"url"`
+	HTMLURL  string         `json:"html_url"`
+	Projects []buddyProject `json:"projects"`
+}
+
+// buddyRequestVariableFile holds the fields of FILE and SSH_KEY variables
+type buddyRequestVariableFile struct {
+	FilePlace  string `json:"file_place,omitempty"`
+	FilePath   string `json:"file_path,omitempty"`
+	FileChmod  string `json:"file_chmod,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
 type buddyRequestWorkspaceVariable struct {
+	buddyRequestVariableFile
+
 	Key         string `json:"key"`
 	Value       string `json:"value"`
 	Type        string `json:"type"`
@@ -116,7 +316,73 @@ type buddyRequestProject struct {
 	Name string `json:"name"`
 }
 
+type buddyRequestIntegration struct {
+	HashId string `json:"hash_id"`
+}
+
+type buddyRequestCreateProject struct {
+	Name               string                   `json:"name,omitempty"`
+	DisplayName        string                   `json:"display_name"`
+	Integration        *buddyRequestIntegration `json:"integration,omitempty"`
+	ExternalProjectId  string                   `json:"external_project_id,omitempty"`
+	GitLabProjectId    string                   `json:"git_lab_project_id,omitempty"`
+	CustomRepoUrl      string                   `json:"custom_repo_url,omitempty"`
+	CustomRepoUser     string                   `json:"custom_repo_user,omitempty"`
+	CustomRepoPass     string                   `json:"custom_repo_pass,omitempty"`
+	CustomRepoSshKeyId int                      `json:"custom_repo_ssh_key_id,omitempty"`
+}
+
+type buddyRequestUpdateProject struct {
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
+
+type buddyRequestPipeline struct {
+	Name                      string                          `json:"name"`
+	On                        string                          `json:"on"`
+	Refs                      []string                        `json:"refs"`
+	Events                    []buddyPipelineEvent            `json:"events"`
+	TriggerConditions         []buddyPipelineTriggerCondition `json:"trigger_conditions"`
+	Cron                      string                          `json:"cron,omitempty"`
+	Delay                     int                             `json:"delay,omitempty"`
+	StartDate                 string                          `json:"start_date,omitempty"`
+	Priority                  string                          `json:"priority,omitempty"`
+	ExecutionMessageTemplate  string                          `json:"execution_message_template"`
+	TargetSiteUrl             string                          `json:"target_site_url"`
+	AlwaysFromScratch         bool                            `json:"always_from_scratch"`
+	FailOnPrepareEnvWarning   bool                            `json:"fail_on_prepare_env_warning"`
+	AutoClearCache            bool                            `json:"auto_clear_cache"`
+	NoSkipToMostRecent        bool                            `json:"no_skip_to_most_recent"`
+	DoNotCreateCommitStatus   bool                            `json:"do_not_create_commit_status"`
+	IgnoreFailOnProjectStatus bool                            `json:"ignore_fail_on_project_status"`
+	Paused                    bool                            `json:"paused"`
+}
+
+// pipelineActionCommandTypes are the action types running commands in a Docker image.
+// Their commands and image are always sent, so clearing them removes them from the action.
+var pipelineActionCommandTypes = map[string]bool{
+	"BUILD": true,
+}
+
+type buddyRequestPipelineAction struct {
+	Name                  string                `json:"name"`
+	Type                  string                `json:"type"`
+	TriggerTime           string                `json:"trigger_time"`
+	RunOnlyOnFirstFailure bool                  `json:"run_only_on_first_failure"`
+	ExecuteCommands       []string              `json:"execute_commands,omitempty"`
+	DockerImageName       string                `json:"docker_image_name,omitempty"`
+	DockerImageTag        string                `json:"docker_image_tag,omitempty"`
+	Variables             []buddyActionVariable `json:"variables"`
+	AfterActionId         int                   `json:"after_action_id,omitempty"`
+
+	// Settings holds action specific fields which are sent along the typed ones
+	Settings map[string]interface{} `json:"-"`
+}
+
 type buddyRequestProjectVariable struct {
+	buddyRequestVariableFile
+
 	Key         string              `json:"key"`
 	Value       string              `json:"value"`
 	Type        string              `json:"type"`
@@ -126,36 +392,157 @@ type buddyRequestProjectVariable struct {
 	Project     buddyRequestProject `json:"project"`
 }
 
+type buddyRequestPipelineVariable struct {
+	buddyRequestVariableFile
+
+	Key         string              `json:"key"`
+	Value       string              `json:"value"`
+	Type        string              `json:"type"`
+	Settable    bool                `json:"settable"`
+	Encrypted   bool                `json:"encrypted"`
+	Description string              `json:"description"`
+	Project     buddyRequestProject `json:"project"`
+	Pipeline    buddyId             `json:"pipeline"`
+}
+
+type buddyRequestActionVariable struct {
+	buddyRequestVariableFile
+
+	Key         string              `json:"key"`
+	Value       string              `json:"value"`
+	Type        string              `json:"type"`
+	Settable    bool                `json:"settable"`
+	Encrypted   bool                `json:"encrypted"`
+	Description string              `json:"description"`
+	Project     buddyRequestProject `json:"project"`
+	Pipeline    buddyId             `json:"pipeline"`
+	Action      buddyId             `json:"action"`
+}
+
+type buddyRequestEnvironment struct {
+	Id string `json:"id"`
+}
+
+type buddyRequestEnvironmentVariable struct {
+	buddyRequestVariableFile
+
+	Key         string                  `json:"key"`
+	Value       string                  `json:"value"`
+	Type        string                  `json:"type"`
+	Settable    bool                    `json:"settable"`
+	Encrypted   bool                    `json:"encrypted"`
+	Description string                  `json:"description"`
+	Project     buddyRequestProject     `json:"project"`
+	Environment buddyRequestEnvironment `json:"environment"`
+}
+
 type buddyRequestProjectMember struct {
 	Id            string  `json:"id"`
 	PermissionSet buddyId `json:"permission_set"`
 }
 
+type buddyRequestCustomPermissionSet struct {
+	Name                  string `json:"name"`
+	Description           string `json:"description"`
+	PipelineAccessLevel   string `json:"pipeline_access_level"`
+	RepositoryAccessLevel string `json:"repository_access_level"`
+	SandboxAccessLevel    string `json:"sandbox_access_level"`
+}
+
+type buddyRequestProjectGroup struct {
+	Id            string  `json:"id"`
+	PermissionSet buddyId `json:"permission_set"`
+}
+
 type buddyRequestPermissionSet struct {
 	PermissionSet buddyId `json:"permission_set"`
 }
 
+type buddyRequestGroup struct {
+	Name                      string `json:"name"`
+	Description               string `json:"description"`
+	AutoAssignToNewProjects   bool   `json:"auto_assign_to_new_projects"`
+	AutoAssignPermissionSetId int    `json:"auto_assign_permission_set_id,omitempty"`
+}
+
+type buddyRequestGroupMember struct {
+	Id string `json:"id"`
+}
+
 type buddyClient interface {
-	CreateWorkspaceVariable(variable buddyRequestWorkspaceVariable) (*buddyResponseWorkspaceVariable, error)
-	ReadWorkspaceVariable(id string) (*buddyResponseWorkspaceVariable, error)
-	UpdateWorkspaceVariable(id string, variable buddyRequestWorkspaceVariable) (*buddyResponseWorkspaceVariable, error)
+	CreateWorkspaceVariable(ctx context.Context, variable buddyRequestWorkspaceVariable) (*buddyResponseWorkspaceVariable, error)
+	ReadWorkspaceVariable(ctx context.Context, id string) (*buddyResponseWorkspaceVariable, error)
+	UpdateWorkspaceVariable(ctx context.Context, id string, variable buddyRequestWorkspaceVariable) (*buddyResponseWorkspaceVariable, error)
+
+	CreateProjectVariable(ctx context.Context, variable buddyRequestProjectVariable) (*buddyResponseProjectVariable, error)
+	ReadProjectVariable(ctx context.Context, id string) (*buddyResponseProjectVariable, error)
+	UpdateProjectVariable(ctx context.Context, id string, variable buddyRequestProjectVariable) (*buddyResponseProjectVariable, error)
+
+	CreatePipelineVariable(ctx context.Context, variable buddyRequestPipelineVariable) (*buddyResponsePipelineVariable, error)
+	ReadPipelineVariable(ctx context.Context, id string) (*buddyResponsePipelineVariable, error)
+	UpdatePipelineVariable(ctx context.Context, id string, variable buddyRequestPipelineVariable) (*buddyResponsePipelineVariable, error)
+
+	CreateActionVariable(ctx context.Context, variable buddyRequestActionVariable) (*buddyResponseActionVariable, error)
+	ReadActionVariable(ctx context.Context, id string) (*buddyResponseActionVariable, error)
+	UpdateActionVariable(ctx context.Context, id string, variable buddyRequestActionVariable) (*buddyResponseActionVariable, error)
+
+	CreateEnvironmentVariable(ctx context.Context, variable buddyRequestEnvironmentVariable) (*buddyResponseEnvironmentVariable, error)
+	ReadEnvironmentVariable(ctx context.Context, id string) (*buddyResponseEnvironmentVariable, error)
+	UpdateEnvironmentVariable(ctx context.Context, id string, variable buddyRequestEnvironmentVariable) (*buddyResponseEnvironmentVariable, error)
+
+	DeleteVariable(ctx context.Context, id string) error
+
+	CreateWorkspaceMember(ctx context.Context, email string) (*buddyResponseWorkspaceMember, error)
+	ReadWorkspaceMember(ctx context.Context, id string) (*buddyResponseWorkspaceMember, error)
+	DeleteWorkspaceMember(ctx context.Context, id string) error
+
+	SetAdminRight(ctx context.Context, id string, admin bool) (*buddyResponseWorkspaceMember, error)
+	ResendInvitation(ctx context.Context, id string) error
+
+	CreateProjectMember(ctx context.Context, projectName string, variable buddyRequestProjectMember) (*buddyResponseProjectMember, error)
+	ReadProjectMember(ctx context.Context, projectName string, memberId string) (*buddyResponseProjectMember, error)
+	UpdateProjectMember(ctx context.Context, projectName string, memberId string, variable buddyRequestPermissionSet) (*buddyResponseProjectMember, error)
+	DeleteProjectMember(ctx context.Context, projectName string, memberId string) error
+	ListProjectMembers(ctx context.Context, projectName string) ([]buddyWorkspaceMember, error)
+
+	CreateProjectGroup(ctx context.Context, projectName string, group buddyRequestProjectGroup) (*buddyResponseProjectGroup, error)
+	ReadProjectGroup(ctx context.Context, projectName string, groupId string) (*buddyResponseProjectGroup, error)
+	UpdateProjectGroup(ctx context.Context, projectName string, groupId string, permissionSet buddyRequestPermissionSet) (*buddyResponseProjectGroup, error)
+	DeleteProjectGroup(ctx context.Context, projectName string, groupId string) error
+
+	CreatePermissionSet(ctx context.Context, permissionSet buddyRequestCustomPermissionSet) (*buddyPermissionSet, error)
+	ReadPermissionSet(ctx context.Context, id string) (*buddyPermissionSet, error)
+	UpdatePermissionSet(ctx context.Context, id string, permissionSet buddyRequestCustomPermissionSet) (*buddyPermissionSet, error)
+	DeletePermissionSet(ctx context.Context, id string) error
+	ListPermissionSets(ctx context.Context) ([]buddyPermissionSet, error)
 
-	CreateProjectVariable(variable buddyRequestProjectVariable) (*buddyResponseProjectVariable, error)
-	ReadProjectVariable(id string) (*buddyResponseProjectVariable, error)
-	UpdateProjectVariable(id string, variable buddyRequestProjectVariable) (*buddyResponseProjectVariable, error)
+	CreateGroup(ctx context.Context, group buddyRequestGroup) (*buddyResponseGroup, error)
+	ReadGroup(ctx context.Context, id string) (*buddyResponseGroup, error)
+	UpdateGroup(ctx context.Context, id string, group buddyRequestGroup) (*buddyResponseGroup, error)
+	DeleteGroup(ctx context.Context, id string) error
 
-	DeleteVariable(id string) error
+	CreateGroupMember(ctx context.Context, groupId string, memberId string) (*buddyResponseWorkspaceMember, error)
+	ReadGroupMember(ctx context.Context, groupId string, memberId string) (*buddyResponseWorkspaceMember, error)
+	DeleteGroupMember(ctx context.Context, groupId string, memberId string) error
 
-	CreateWorkspaceMember(email string) (*buddyResponseWorkspaceMember, error)
-	ReadWorkspaceMember(id string) (*buddyResponseWorkspaceMember, error)
-	DeleteWorkspaceMember(id string) error
+	GetUser(ctx context.Context, email string) (*buddyWorkspaceMember, error)
+	GetCurrentUser(ctx context.Context) (*buddyWorkspaceMember, error)
+	ListMembers(ctx context.Context) ([]buddyWorkspaceMember, error)
 
-	SetAdminRight(id string, admin bool) (*buddyResponseWorkspaceMember, error)
+	CreateProject(ctx context.Context, project buddyRequestCreateProject) (*buddyResponseProject, error)
+	ReadProject(ctx context.Context, projectName string) (*buddyResponseProject, error)
+	UpdateProject(ctx context.Context, projectName string, project buddyRequestUpdateProject) (*buddyResponseProject, error)
+	DeleteProject(ctx context.Context, projectName string) error
+	ListProjects(ctx context.Context, status string) ([]buddyProject, error)
 
-	CreateProjectMember(projectName string, variable buddyRequestProjectMember) (*buddyResponseProjectMember, error)
-	ReadProjectMember(projectName string, memberId string) (*buddyResponseProjectMember, error)
-	UpdateProjectMember(projectName string, memberId string, variable buddyRequestPermissionSet) (*buddyResponseProjectMember, error)
-	DeleteProjectMember(projectName string, memberId string) error
+	CreatePipeline(ctx context.Context, projectName string, pipeline buddyRequestPipeline) (*buddyResponsePipeline, error)
+	ReadPipeline(ctx context.Context, projectName string, pipelineId string) (*buddyResponsePipeline, error)
+	UpdatePipeline(ctx context.Context, projectName string, pipelineId string, pipeline buddyRequestPipeline) (*buddyResponsePipeline, error)
+	DeletePipeline(ctx context.Context, projectName string, pipelineId string) error
 
-	GetUser(email string) (*buddyWorkspaceMember, error)
+	CreatePipelineAction(ctx context.Context, projectName string, pipelineId string, action buddyRequestPipelineAction) (*buddyResponsePipelineAction, error)
+	ReadPipelineAction(ctx context.Context, projectName string, pipelineId string, actionId string) (*buddyResponsePipelineAction, error)
+	UpdatePipelineAction(ctx context.Context, projectName string, pipelineId string, actionId string, action buddyRequestPipelineAction) (*buddyResponsePipelineAction, error)
+	DeletePipelineAction(ctx context.Context, projectName string, pipelineId string, actionId string) error
+	ListPipelineActions(ctx context.Context, projectName string, pipelineId string) ([]buddyResponsePipelineAction, error)
 }
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePermissionSet() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_permission_set` get information about a permission set in the workspace, " +
+			"including the built-in permission sets such as `Developer` and `Read Only`",
+
+		ReadContext: dataSourcePermissionSetRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Permission set name",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Permission set description",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Permission set type, e.g. `DEVELOPER`, `READ_ONLY` or `CUSTOM`",
+			},
+			"pipeline_access_level": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Access level to pipelines",
+			},
+			"repository_access_level": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Access level to the repository",
+			},
+			"sandbox_access_level": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Access level to sandboxes",
+			},
+			"html_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Permission set URL in the Buddy web interface",
+			},
+		},
+	}
+}
+
+func dataSourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	name := d.Get("name").(string)
+
+	permissionSets, err := client.ListPermissionSets(ctx)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	var matches []buddyPermissionSet
+	for _, permissionSet := range permissionSets {
+		if permissionSet.Name == name {
+			matches = append(matches, permissionSet)
+		}
+	}
+
+	if len(matches) == 0 {
+		return diag.FromErr(fmt.Errorf("Permission set not found: " + name))
+	}
+
+	if len(matches) > 1 {
+		return diag.FromErr(fmt.Errorf("Found %v permission sets named %v", len(matches), name))
+	}
+
+	if diags := setPermissionSet(d, &matches[0]); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(matches[0].Id))
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourcePermissionSet(t *testing.T) {
+	fake := newFakeBuddy(t)
+	developer := fake.permissionSetByName("Developer")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "buddy_permission_set" "developer" {
+  name = "Developer"
+}
+
+resource "buddy_permission_set" "custom" {
+  name                    = "Release managers"
+  pipeline_access_level   = "RUN_ONLY"
+  repository_access_level = "READ_ONLY"
+}
+
+data "buddy_permission_set" "custom" {
+  name = buddy_permission_set.custom.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.buddy_permission_set.developer", "id", strconv.Itoa(developer.Id)),
+					resource.TestCheckResourceAttr("data.buddy_permission_set.developer", "type", "DEVELOPER"),
+					resource.TestCheckResourceAttr("data.buddy_permission_set.developer", "pipeline_access_level", "RUN_ONLY"),
+					resource.TestCheckResourceAttrPair("data.buddy_permission_set.custom", "id", "buddy_permission_set.custom", "id"),
+					resource.TestCheckResourceAttr("data.buddy_permission_set.custom", "type", "CUSTOM"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourcePermissionSet_notFound(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "buddy_permission_set" "test" {
+  name = "Missing"
+}
+`,
+				ExpectError: regexp.MustCompile("Permission set not found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceProject() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_project` get information about a project in the workspace",
+
+		ReadContext: dataSourceProjectRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name as used in the project URL",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Project display name",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Project status. Either `ACTIVE` or `CLOSED`",
+			},
+			"html_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Project URL in the Buddy web interface",
+			},
+			"create_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Project creation date",
+			},
+		},
+	}
+}
+
+func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	name := d.Get("name").(string)
+
+	project, err := client.ReadProject(ctx, name)
+	if IsNotFound(err) {
+		return diag.FromErr(fmt.Errorf("Project not found: " + name))
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	if err := d.Set("display_name", project.DisplayName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("status", project.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("html_url", project.HTMLURL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("create_date", project.CreateDate); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(project.Name)
+
+	return nil
+}
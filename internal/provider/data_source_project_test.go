@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceProject(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project").DisplayName = "My Project"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "buddy_project" "test" {
+  name = "my-project"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.buddy_project.test", "id", "my-project"),
+					resource.TestCheckResourceAttr("data.buddy_project.test", "display_name", "My Project"),
+					resource.TestCheckResourceAttr("data.buddy_project.test", "status", "ACTIVE"),
+					resource.TestCheckResourceAttrSet("data.buddy_project.test", "html_url"),
+					resource.TestCheckResourceAttrSet("data.buddy_project.test", "create_date"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceProject_notFound(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "buddy_project" "test" {
+  name = "missing-project"
+}
+`,
+				ExpectError: regexp.MustCompile("Project not found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceProjects() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_projects` list projects in the workspace",
+
+		ReadContext: dataSourceProjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ACTIVE", "CLOSED"}, false),
+				Description:  "Only list projects with the given status. Valid values are `ACTIVE` and `CLOSED`",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only list projects whose name matches the regular expression",
+			},
+			"projects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of projects matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Project name as used in the project URL",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Project display name",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Project status",
+						},
+						"html_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Project URL in the Buddy web interface",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceProjectsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	status := d.Get("status").(string)
+	nameRegex := d.Get("name_regex").(string)
+
+	projects, err := client.ListProjects(ctx, status)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	var re *regexp.Regexp
+	if nameRegex != "" {
+		re = regexp.MustCompile(nameRegex)
+	}
+
+	result := make([]interface{}, 0, len(projects))
+	for _, project := range projects {
+		if re != nil && !re.MatchString(project.Name) {
+			continue
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":         project.Name,
+			"display_name": project.DisplayName,
+			"status":       project.Status,
+			"html_url":     project.HTMLURL,
+		})
+	}
+
+	if err := d.Set("projects", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(status + ":" + nameRegex)))
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceProjects(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("backend")
+	fake.addProject("backend-legacy").Status = "CLOSED"
+	fake.addProject("frontend")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "buddy_projects" "all" {}
+
+data "buddy_projects" "active_backend" {
+  status     = "ACTIVE"
+  name_regex = "^backend"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.buddy_projects.all", "projects.#", "3"),
+					resource.TestCheckResourceAttr("data.buddy_projects.active_backend", "projects.#", "1"),
+					resource.TestCheckResourceAttr("data.buddy_projects.active_backend", "projects.0.name", "backend"),
+					resource.TestCheckResourceAttr("data.buddy_projects.active_backend", "projects.0.status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
@@ -3,38 +3,75 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceWorkspaceMember() *schema.Resource {
+	lookup := []string{"id", "email", "name"}
+
 	return &schema.Resource{
-		Description: "`buddy_workspace_member` get information about workspace member",
+		Description: "`buddy_workspace_member` get information about workspace member. " +
+			"The member is looked up by exactly one of `id`, `email` or `name`.",
 
 		ReadContext: dataSourceWorkspaceMemberRead,
 
 		Schema: map[string]*schema.Schema{
 			"email": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Email address of the member",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: lookup,
+				Description:  "Email address of the member. The email is matched ignoring case",
 			},
 			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: lookup,
+				Description:  "Member name. The lookup fails when more than one member has the name",
+			},
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: lookup,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-9]+$`), "Member ID must be a number"),
+				Description:  "Member ID",
+			},
+			"title": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Member name",
+				Description: "Member title",
+			},
+			"admin": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the member is an administrator of the workspace",
 			},
-			"id": {
+			"workspace_owner": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the member owns the workspace",
+			},
+			"avatar_url": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Member ID",
+				Description: "Member avatar URL",
 			},
-			"title": {
+			"html_url": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Member title",
+				Description: "Member URL in the Buddy web interface",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Member URL in the Buddy API",
 			},
 		},
 	}
@@ -42,15 +79,28 @@ func dataSourceWorkspaceMember() *schema.Resource {
 
 func dataSourceWorkspaceMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(buddyClient)
-	email := d.Get("email").(string)
 
-	member, err := client.GetUser(email)
+	var member *buddyWorkspaceMember
+	var lookup string
+	var err error
+
+	if id, ok := d.GetOk("id"); ok {
+		lookup = id.(string)
+		member, err = readWorkspaceMemberById(ctx, client, lookup)
+	} else if name, ok := d.GetOk("name"); ok {
+		lookup = name.(string)
+		member, err = findWorkspaceMemberByName(ctx, client, lookup)
+	} else {
+		lookup = d.Get("email").(string)
+		member, err = client.GetUser(ctx, lookup)
+	}
+
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
 	if member.Url == "" && member.Id == 0 {
-		return diag.FromErr(fmt.Errorf("User not found: " + email))
+		return diag.FromErr(fmt.Errorf("User not found: " + lookup))
 	}
 
 	if err := d.Set("email", member.Email); err != nil {
@@ -65,6 +115,26 @@ func dataSourceWorkspaceMemberRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("admin", member.Admin); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("workspace_owner", member.WorkspaceOwner); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("avatar_url", member.AvatarUrl); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("html_url", member.HTMLURL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("url", member.Url); err != nil {
+		return diag.FromErr(err)
+	}
+
 	id := strconv.Itoa(member.Id)
 	if err := d.Set("id", id); err != nil {
 		return diag.FromErr(err)
@@ -74,3 +144,43 @@ func dataSourceWorkspaceMemberRead(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+// readWorkspaceMemberById returns an empty member when the ID doesn't exist, like GetUser
+func readWorkspaceMemberById(ctx context.Context, client buddyClient, id string) (*buddyWorkspaceMember, error) {
+	member, err := client.ReadWorkspaceMember(ctx, id)
+	if IsNotFound(err) {
+		return &buddyWorkspaceMember{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := buddyWorkspaceMember(*member)
+	return &data, nil
+}
+
+func findWorkspaceMemberByName(ctx context.Context, client buddyClient, name string) (*buddyWorkspaceMember, error) {
+	members, err := client.ListMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []buddyWorkspaceMember
+	for _, member := range members {
+		if member.Name == name {
+			matches = append(matches, member)
+		}
+	}
+
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("Found %v workspace members named %v, look the member up by email or id instead", len(matches), name)
+	}
+
+	var data buddyWorkspaceMember
+	if len(matches) == 1 {
+		data = matches[0]
+	}
+
+	return &data, nil
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceWorkspaceMember(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addMember("jane@example.com", "Jane Doe")
+	john := fake.addMember("john@example.com", "John Doe")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "buddy_workspace_member" "test" {
+  email = "john@example.com"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.buddy_workspace_member.test", "id", strconv.Itoa(john.Id)),
+					resource.TestCheckResourceAttr("data.buddy_workspace_member.test", "name", "John Doe"),
+					resource.TestCheckResourceAttr("data.buddy_workspace_member.test", "email", "john@example.com"),
+					resource.TestCheckResourceAttr("data.buddy_workspace_member.test", "admin", "false"),
+					resource.TestCheckResourceAttrSet("data.buddy_workspace_member.test", "avatar_url"),
+					resource.TestCheckResourceAttrSet("data.buddy_workspace_member.test", "html_url"),
+					resource.TestCheckResourceAttrSet("data.buddy_workspace_member.test", "url"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceWorkspaceMember_byIdAndName(t *testing.T) {
+	fake := newFakeBuddy(t)
+	jane := fake.addMember("jane@example.com", "Jane Doe")
+	jane.Admin = true
+	john := fake.addMember("john@example.com", "John Doe")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "buddy_workspace_member" "by_id" {
+  id = "%v"
+}
+
+data "buddy_workspace_member" "by_name" {
+  name = "John Doe"
+}
+`, jane.Id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.buddy_workspace_member.by_id", "email", "jane@example.com"),
+					resource.TestCheckResourceAttr("data.buddy_workspace_member.by_id", "admin", "true"),
+					resource.TestCheckResourceAttr("data.buddy_workspace_member.by_name", "id", strconv.Itoa(john.Id)),
+					resource.TestCheckResourceAttr("data.buddy_workspace_member.by_name", "email", "john@example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceWorkspaceMember_exactlyOneLookup(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "buddy_workspace_member" "test" {
+  email = "john@example.com"
+  name  = "John Doe"
+}
+`,
+				ExpectError: regexp.MustCompile("only one of"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceWorkspaceMember_notFound(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "buddy_workspace_member" "test" {
+  email = "nobody@example.com"
+}
+`,
+				ExpectError: regexp.MustCompile("User not found"),
+			},
+		},
+	})
+}
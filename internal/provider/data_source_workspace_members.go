@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceWorkspaceMembers() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_workspace_members` list members of the workspace",
+
+		ReadContext: dataSourceWorkspaceMembersRead,
+
+		Schema: map[string]*schema.Schema{
+			"admin_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only list members with administrator rights in the workspace",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only list members whose name matches the regular expression",
+			},
+			"email_domain": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Only list members whose email address belongs to the domain, e.g. `example.com`",
+			},
+			"members": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of members matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Member ID",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Member email address",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Member name",
+						},
+						"title": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Member title",
+						},
+						"admin": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the member is an administrator of the workspace",
+						},
+						"workspace_owner": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the member owns the workspace",
+						},
+						"avatar_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Member avatar URL",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceWorkspaceMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	adminOnly := d.Get("admin_only").(bool)
+	nameRegex := d.Get("name_regex").(string)
+	emailDomain := strings.TrimPrefix(d.Get("email_domain").(string), "@")
+
+	members, err := client.ListMembers(ctx)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	var re *regexp.Regexp
+	if nameRegex != "" {
+		re = regexp.MustCompile(nameRegex)
+	}
+
+	result := make([]interface{}, 0, len(members))
+	for _, member := range members {
+		if adminOnly && !member.Admin {
+			continue
+		}
+
+		if re != nil && !re.MatchString(member.Name) {
+			continue
+		}
+
+		if emailDomain != "" && !strings.HasSuffix(strings.ToLower(member.Email), "@"+strings.ToLower(emailDomain)) {
+			continue
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":              strconv.Itoa(member.Id),
+			"email":           member.Email,
+			"name":            member.Name,
+			"title":           member.Title,
+			"admin":           member.Admin,
+			"workspace_owner": member.WorkspaceOwner,
+			"avatar_url":      member.AvatarUrl,
+		})
+	}
+
+	if err := d.Set("members", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strconv.FormatBool(adminOnly) + ":" + nameRegex + ":" + emailDomain)))
+
+	return nil
+}
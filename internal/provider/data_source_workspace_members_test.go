@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDataSourceWorkspaceMembers(t *testing.T) {
+	fake := newFakeBuddy(t)
+	jane := fake.addMember("jane@example.com", "Jane Doe")
+	jane.Admin = true
+	fake.addMember("john@example.com", "John Doe")
+	fake.addMember("joan@contractor.io", "Joan Smith")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "buddy_workspace_members" "all" {}
+
+data "buddy_workspace_members" "admins" {
+  admin_only = true
+}
+
+data "buddy_workspace_members" "example_does" {
+  name_regex   = "Doe$"
+  email_domain = "example.com"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.buddy_workspace_members.all", "members.#", "3"),
+					resource.TestCheckResourceAttr("data.buddy_workspace_members.admins", "members.#", "1"),
+					resource.TestCheckResourceAttr("data.buddy_workspace_members.admins", "members.0.id", strconv.Itoa(jane.Id)),
+					resource.TestCheckResourceAttr("data.buddy_workspace_members.admins", "members.0.email", "jane@example.com"),
+					resource.TestCheckResourceAttr("data.buddy_workspace_members.admins", "members.0.admin", "true"),
+					resource.TestCheckResourceAttr("data.buddy_workspace_members.example_does", "members.#", "2"),
+					resource.TestCheckResourceAttr("data.buddy_workspace_members.example_does", "members.1.name", "John Doe"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceWorkspaceMembers_forEachProjectMember(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	fake.addMember("jane@example.com", "Jane Doe")
+	fake.addMember("john@example.com", "John Doe")
+	developer := fake.permissionSetByName("Developer")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckProjectMemberDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "buddy_workspace_members" "all" {}
+
+resource "buddy_project_member" "all" {
+  for_each = { for member in data.buddy_workspace_members.all.members : member.email => member.id }
+
+  project_name      = "my-project"
+  member_id         = each.value
+  permission_set_id = ` + strconv.Itoa(developer.Id) + `
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("buddy_project_member.all[\"jane@example.com\"]", "member_id", "data.buddy_workspace_members.all", "members.0.id"),
+					resource.TestCheckResourceAttrPair("buddy_project_member.all[\"john@example.com\"]", "member_id", "data.buddy_workspace_members.all", "members.1.id"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceWorkspaceMembersRead_stringIds(t *testing.T) {
+	fake := newFakeBuddy(t)
+	jane := fake.addMember("jane@example.com", "Jane Doe")
+
+	r := dataSourceWorkspaceMembers()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	if diags := r.ReadContext(context.Background(), d, fake.client()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if id := d.Get("members.0.id"); id != strconv.Itoa(jane.Id) {
+		t.Fatalf("expected member ID %q as a string, got %#v", strconv.Itoa(jane.Id), id)
+	}
+}
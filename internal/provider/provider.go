@@ -2,15 +2,19 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var (
 	user_agent string
 )
 
+const defaultResourceTimeout = 5 * time.Minute
+
 // New - create a new Buddy provider
 func New(version string) *schema.Provider {
 	user_agent = "terraform-buddy-provider/" + version
@@ -35,17 +39,53 @@ func New(version string) *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("BUDDY_VERIFY_SSL", true),
 				Description: "Whether to verify TLS connection to the Buddy URL",
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BUDDY_MAX_RETRIES", defaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of retries of a request failed because of rate limiting, a temporary server error or a broken connection",
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BUDDY_RETRY_MAX_WAIT", defaultRetryMaxWait),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum time in seconds to wait between retries",
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BUDDY_REQUESTS_PER_SECOND", 0.0),
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of requests per second sent to Buddy by the provider. Requests above the limit wait for their turn. Set to `0` to disable the limit",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"buddy_workspace_variable": resourceWorkspaceVariable(),
-			"buddy_workspace_member":   resourceWorkspaceMember(),
-			"buddy_project_member":     resourceProjectMember(),
-			"buddy_project_variable":   resourceProjectVariable(),
+			"buddy_workspace_variable":   resourceWorkspaceVariable(),
+			"buddy_workspace_member":     resourceWorkspaceMember(),
+			"buddy_project_member":       resourceProjectMember(),
+			"buddy_project_members":      resourceProjectMembers(),
+			"buddy_permission_set":       resourcePermissionSet(),
+			"buddy_project_group":        resourceProjectGroup(),
+			"buddy_project_variable":     resourceProjectVariable(),
+			"buddy_project":              resourceProject(),
+			"buddy_pipeline":             resourcePipeline(),
+			"buddy_pipeline_action":      resourcePipelineAction(),
+			"buddy_pipeline_variable":    resourcePipelineVariable(),
+			"buddy_action_variable":      resourceActionVariable(),
+			"buddy_environment_variable": resourceEnvironmentVariable(),
+			"buddy_group":                resourceGroup(),
+			"buddy_group_member":         resourceGroupMember(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"buddy_workspace_member": dataSourceWorkspaceMember(),
+			"buddy_workspace_member":  dataSourceWorkspaceMember(),
+			"buddy_workspace_members": dataSourceWorkspaceMembers(),
+			"buddy_project":           dataSourceProject(),
+			"buddy_permission_set":    dataSourcePermissionSet(),
+			"buddy_projects":          dataSourceProjects(),
 		},
 
 		ConfigureContextFunc: configureProvider,
@@ -54,11 +94,25 @@ func New(version string) *schema.Provider {
 
 func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	config := Config{
-		BuddyURL:  d.Get("buddy_url").(string),
-		Token:     d.Get("token").(string),
-		VerifySSL: d.Get("verify_ssl").(bool),
+		BuddyURL:          d.Get("buddy_url").(string),
+		Token:             d.Get("token").(string),
+		VerifySSL:         d.Get("verify_ssl").(bool),
+		MaxRetries:        d.Get("max_retries").(int),
+		RetryMaxWait:      d.Get("retry_max_wait").(int),
+		RequestsPerSecond: d.Get("requests_per_second").(float64),
 	}
 
 	client := newBuddyClient(&config)
 	return client, nil
 }
+
+// resourceTimeouts returns the default timeouts of a resource. The operation is
+// cancelled, including the request in flight, once its timeout expires.
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultResourceTimeout),
+		Read:   schema.DefaultTimeout(defaultResourceTimeout),
+		Update: schema.DefaultTimeout(defaultResourceTimeout),
+		Delete: schema.DefaultTimeout(defaultResourceTimeout),
+	}
+}
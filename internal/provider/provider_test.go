@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// providerFactories are used to instantiate a provider during acceptance testing.
+// The factory function will be invoked for every Terraform CLI command executed
+// to create a provider server to which the CLI can reattach.
+var providerFactories = map[string]func() (*schema.Provider, error){
+	"buddy": func() (*schema.Provider, error) {
+		return New("dev"), nil
+	},
+}
+
+func TestProvider(t *testing.T) {
+	if err := New("dev").InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestResourceRead_removesMissingResourceFromState(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	cases := map[string]struct {
+		resource *schema.Resource
+		id       string
+	}{
+		"buddy_workspace_variable":   {resourceWorkspaceVariable(), "999"},
+		"buddy_project_variable":     {resourceProjectVariable(), "999"},
+		"buddy_workspace_member":     {resourceWorkspaceMember(), "999"},
+		"buddy_project_member":       {resourceProjectMember(), "my-project:999"},
+		"buddy_project_members":      {resourceProjectMembers(), "missing-project"},
+		"buddy_project":              {resourceProject(), "missing-project"},
+		"buddy_pipeline":             {resourcePipeline(), "my-project:999"},
+		"buddy_pipeline_action":      {resourcePipelineAction(), "my-project:999:999"},
+		"buddy_pipeline_variable":    {resourcePipelineVariable(), "999"},
+		"buddy_action_variable":      {resourceActionVariable(), "999"},
+		"buddy_environment_variable": {resourceEnvironmentVariable(), "999"},
+		"buddy_group":                {resourceGroup(), "999"},
+		"buddy_group_member":         {resourceGroupMember(), "999:999"},
+		"buddy_project_group":        {resourceProjectGroup(), "my-project:999"},
+		"buddy_permission_set":       {resourcePermissionSet(), "999"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := tc.resource.TestResourceData()
+			d.SetId(tc.id)
+
+			diags := tc.resource.ReadContext(context.Background(), d, fake.client())
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if d.Id() != "" {
+				t.Fatalf("expected resource to be removed from state, got ID %q", d.Id())
+			}
+		})
+	}
+}
+
+func TestResourceCreateUpdate_populatesStateFromResponse(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	fake.addProject("team-project")
+	member := fake.addMember("john@example.com", "John")
+	ann := fake.addMember("ann@example.com", "Ann")
+	client := fake.client()
+	ctx := context.Background()
+
+	pipeline, err := client.CreatePipeline(ctx, "my-project", buddyRequestPipeline{Name: "Build", On: "CLICK"})
+	if err != nil {
+		t.Fatalf("CreatePipeline returned an error: %v", err)
+	}
+
+	group, err := client.CreateGroup(ctx, buddyRequestGroup{Name: "Testers"})
+	if err != nil {
+		t.Fatalf("CreateGroup returned an error: %v", err)
+	}
+
+	cases := map[string]struct {
+		resource *schema.Resource
+		config   map[string]interface{}
+		expected map[string]string
+		// gets is the number of GET requests needed to fill the computed attributes
+		gets int
+	}{
+		"buddy_workspace_variable": {
+			resource: resourceWorkspaceVariable(),
+			config:   map[string]interface{}{"key": "WORKSPACE_VAR", "value": "dummy"},
+			expected: map[string]string{"value_hash": "dummy"},
+		},
+		"buddy_project_variable": {
+			resource: resourceProjectVariable(),
+			config:   map[string]interface{}{"key": "PROJECT_VAR", "value": "dummy", "project": "my-project"},
+			expected: map[string]string{"project": "my-project", "value_hash": "dummy"},
+		},
+		"buddy_pipeline_variable": {
+			resource: resourcePipelineVariable(),
+			config:   map[string]interface{}{"key": "PIPELINE_VAR", "value": "dummy", "project": "my-project", "pipeline_id": pipeline.Id},
+			expected: map[string]string{"project": "my-project", "pipeline_id": strconv.Itoa(pipeline.Id)},
+		},
+		"buddy_workspace_member": {
+			resource: resourceWorkspaceMember(),
+			config:   map[string]interface{}{"email": "jane@example.com", "admin": true},
+			expected: map[string]string{"admin": "true", "invitation_status": "PENDING"},
+		},
+		"buddy_project_member": {
+			resource: resourceProjectMember(),
+			config:   map[string]interface{}{"project_name": "my-project", "member_id": strconv.Itoa(member.Id), "permission_set_id": fake.permissionSetByName("Developer").Id},
+			expected: map[string]string{"member_id": strconv.Itoa(member.Id)},
+		},
+		"buddy_project_member by email": {
+			resource: resourceProjectMember(),
+			config:   map[string]interface{}{"project_name": "my-project", "email": "Ann@example.com", "permission_set_id": fake.permissionSetByName("Developer").Id},
+			expected: map[string]string{"member_id": strconv.Itoa(ann.Id), "email": "ann@example.com"},
+			// the email is resolved on create
+			gets: 1,
+		},
+		"buddy_project_members": {
+			resource: resourceProjectMembers(),
+			config: map[string]interface{}{"project_name": "team-project", "member": []interface{}{
+				map[string]interface{}{"member_id": strconv.Itoa(member.Id), "permission_set_id": fake.permissionSetByName("Developer").Id},
+			}},
+			expected: map[string]string{"member.#": "1"},
+			// the live members and the user of the token are read on create and update
+			gets: 4,
+		},
+		"buddy_project": {
+			resource: resourceProject(),
+			config:   map[string]interface{}{"display_name": "Other Project", "status": "CLOSED"},
+			expected: map[string]string{"name": "other-project", "status": "CLOSED"},
+		},
+		"buddy_pipeline": {
+			resource: resourcePipeline(),
+			config:   map[string]interface{}{"project_name": "my-project", "name": "Deploy", "on": "CLICK"},
+			expected: map[string]string{"name": "Deploy", "priority": "NORMAL"},
+		},
+		"buddy_pipeline_action": {
+			resource: resourcePipelineAction(),
+			config:   map[string]interface{}{"project_name": "my-project", "pipeline_id": pipeline.Id, "name": "Run tests", "type": "BUILD"},
+			expected: map[string]string{"position": "1"},
+			gets:     1,
+		},
+		"buddy_group": {
+			resource: resourceGroup(),
+			config:   map[string]interface{}{"name": "Developers", "auto_assign_to_new_projects": true, "auto_assign_permission_set_id": fake.permissionSetByName("Developer").Id},
+			expected: map[string]string{"name": "Developers", "auto_assign_to_new_projects": "true"},
+		},
+		"buddy_group_member": {
+			resource: resourceGroupMember(),
+			config:   map[string]interface{}{"group_id": strconv.Itoa(group.Id), "member_id": strconv.Itoa(member.Id)},
+			expected: map[string]string{"email": "john@example.com", "name": "John"},
+		},
+		"buddy_project_group": {
+			resource: resourceProjectGroup(),
+			config:   map[string]interface{}{"project_name": "my-project", "group_id": strconv.Itoa(group.Id), "permission_set_id": fake.permissionSetByName("Developer").Id},
+			expected: map[string]string{"group_id": strconv.Itoa(group.Id), "permission_set_id": strconv.Itoa(fake.permissionSetByName("Developer").Id)},
+		},
+		"buddy_permission_set": {
+			resource: resourcePermissionSet(),
+			config:   map[string]interface{}{"name": "Release managers", "pipeline_access_level": "RUN_ONLY", "repository_access_level": "READ_ONLY"},
+			expected: map[string]string{"type": "CUSTOM", "sandbox_access_level": "DENIED"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, tc.resource.Schema, tc.config)
+			gets := fake.requestCount(http.MethodGet)
+
+			if diags := tc.resource.CreateContext(ctx, d, client); diags.HasError() {
+				t.Fatalf("unexpected error on create: %v", diags)
+			}
+
+			// Resources without updatable attributes are replaced instead
+			if tc.resource.UpdateContext != nil {
+				if diags := tc.resource.UpdateContext(ctx, d, client); diags.HasError() {
+					t.Fatalf("unexpected error on update: %v", diags)
+				}
+			}
+
+			if n := fake.requestCount(http.MethodGet) - gets; n != tc.gets {
+				t.Fatalf("expected %v GET requests, got %v", tc.gets, n)
+			}
+
+			for key, value := range tc.expected {
+				if actual := d.Get(key); fmt.Sprint(actual) != value {
+					t.Fatalf("expected %v to be %q, got %q", key, value, actual)
+				}
+			}
+		})
+	}
+}
+
+// testAccCheckResourceDisappears removes the resource from the fake Buddy server
+// without going through Terraform, simulating a deletion in the Buddy UI.
+func testAccCheckResourceDisappears(name string, remove func(id string) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Resource not found in state: %v", name)
+		}
+
+		return remove(rs.Primary.ID)
+	}
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_reserve(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	limiter := newRateLimiter(2)
+	limiter.now = func() time.Time { return now }
+
+	// The bucket starts full, so a burst of two requests doesn't wait
+	for i := 0; i < 2; i++ {
+		if delay := limiter.reserve(); delay != 0 {
+			t.Fatalf("expected request %v to pass without waiting, got %v", i+1, delay)
+		}
+	}
+
+	if delay := limiter.reserve(); delay != 500*time.Millisecond {
+		t.Fatalf("expected third request to wait 500ms, got %v", delay)
+	}
+	if delay := limiter.reserve(); delay != time.Second {
+		t.Fatalf("expected fourth request to wait 1s, got %v", delay)
+	}
+
+	now = now.Add(10 * time.Second)
+	if delay := limiter.reserve(); delay != 0 {
+		t.Fatalf("expected the bucket to refill, got %v", delay)
+	}
+}
+
+func TestRateLimiter_waitCancelled(t *testing.T) {
+	limiter := newRateLimiter(0.01)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.Wait(ctx); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRateLimitTransport_parallelRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &rateLimitTransport{
+		base:    http.DefaultTransport,
+		limiter: newRateLimiter(20),
+	}}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	// 20 requests fit in the initial burst, the remaining 10 are spread over half a second
+	if elapsed := time.Since(start); elapsed < 450*time.Millisecond {
+		t.Fatalf("expected requests to be throttled, 30 requests took %v", elapsed)
+	}
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceActionVariable() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_action_variable` manages variable under the action scope.\n\n" +
+			"Action scoped variable is accessible only to a single action of a pipeline. " +
+			"Use this variable to store value that is specific to one action.",
+
+		CreateContext: resourceActionVariableCreate,
+		ReadContext:   resourceActionVariableRead,
+		UpdateContext: resourceActionVariableUpdate,
+		DeleteContext: resourceActionVariableDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: variableCustomizeDiff,
+		Schema: variableSchema(map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project name of the action",
+			},
+			"pipeline_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Pipeline ID of the action",
+			},
+			"action_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Action ID where variable will be created",
+			},
+		}),
+	}
+}
+
+func resourceActionVariableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	v, err := client.CreateActionVariable(ctx, expandActionVariable(d))
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	d.SetId(strconv.Itoa(v.Id))
+	return setActionVariable(d, v)
+}
+
+func resourceActionVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	id := d.Id()
+	data, err := client.ReadActionVariable(ctx, id)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Action variable %v not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setActionVariable(d, data)
+}
+
+func setActionVariable(d *schema.ResourceData, data *buddyResponseActionVariable) diag.Diagnostics {
+	if diags := setVariable(d, data.buddyResponseVariable); diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("project", data.Project.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("pipeline_id", data.Pipeline.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("action_id", data.Action.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceActionVariableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	id := d.Id()
+	v, err := client.UpdateActionVariable(ctx, id, expandActionVariable(d))
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setActionVariable(d, v)
+}
+
+func resourceActionVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	id := d.Id()
+	err := client.DeleteVariable(ctx, id)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+	return nil
+}
+
+func expandActionVariable(d *schema.ResourceData) buddyRequestActionVariable {
+	return buddyRequestActionVariable{
+		Key:         d.Get("key").(string),
+		Value:       d.Get("value").(string),
+		Type:        d.Get("type").(string),
+		Description: d.Get("description").(string),
+		Settable:    d.Get("settable").(bool),
+		Encrypted:   d.Get("encrypted").(bool),
+		Project: buddyRequestProject{
+			Name: d.Get("project").(string),
+		},
+		Pipeline: buddyId{
+			Id: d.Get("pipeline_id").(int),
+		},
+		Action: buddyId{
+			Id: d.Get("action_id").(int),
+		},
+
+		buddyRequestVariableFile: expandVariableFile(d),
+	}
+}
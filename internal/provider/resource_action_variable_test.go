@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceActionVariable(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVariableDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceActionVariableConfig(fake, "staging", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_action_variable.test", "key", "ENVIRONMENT"),
+					resource.TestCheckResourceAttr("buddy_action_variable.test", "project", "my-project"),
+					resource.TestCheckResourceAttrPair("buddy_action_variable.test", "pipeline_id", "buddy_pipeline.test", "pipeline_id"),
+					resource.TestCheckResourceAttrPair("buddy_action_variable.test", "action_id", "buddy_pipeline_action.test", "action_id"),
+					resource.TestCheckResourceAttr("buddy_action_variable.test", "encrypted", "true"),
+					resource.TestMatchResourceAttr("buddy_action_variable.test", "value_hash", regexpSecureValue),
+				),
+			},
+			{
+				Config: testAccResourceActionVariableConfig(fake, "production", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_action_variable.test", "encrypted", "false"),
+					resource.TestCheckResourceAttr("buddy_action_variable.test", "value", "production"),
+				),
+			},
+			{
+				ResourceName:            "buddy_action_variable.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"type"},
+			},
+		},
+	})
+}
+
+func testAccResourceActionVariableConfig(fake *fakeBuddy, value string, encrypted bool) string {
+	return fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_pipeline" "test" {
+  project_name = "my-project"
+  name         = "Build"
+  on           = "CLICK"
+}
+
+resource "buddy_pipeline_action" "test" {
+  project_name = buddy_pipeline.test.project_name
+  pipeline_id  = buddy_pipeline.test.pipeline_id
+  name         = "Run tests"
+  type         = "BUILD"
+}
+
+resource "buddy_action_variable" "test" {
+  project     = buddy_pipeline.test.project_name
+  pipeline_id = buddy_pipeline.test.pipeline_id
+  action_id   = buddy_pipeline_action.test.action_id
+  key         = "ENVIRONMENT"
+  value       = %q
+  encrypted   = %t
+}
+`, value, encrypted)
+}
+
+func TestAccResourceActionVariable_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVariableDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceActionVariableConfig(fake, "staging", false),
+				Check: testAccCheckResourceDisappears("buddy_action_variable.test", func(id string) error {
+					variableId, err := strconv.Atoi(id)
+					if err != nil {
+						return err
+					}
+					fake.removeVariable(variableId)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
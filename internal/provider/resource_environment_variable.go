@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceEnvironmentVariable() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_environment_variable` manages variable under the environment scope.\n\n" +
+			"Environment scoped variable is accessible to the pipelines and actions deploying to the environment. " +
+			"Use this variable to store value that differs between environments, e.g. staging and production.",
+
+		CreateContext: resourceEnvironmentVariableCreate,
+		ReadContext:   resourceEnvironmentVariableRead,
+		UpdateContext: resourceEnvironmentVariableUpdate,
+		DeleteContext: resourceEnvironmentVariableDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: variableCustomizeDiff,
+		Schema: variableSchema(map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project name of the environment",
+			},
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Environment ID where variable will be created",
+			},
+		}),
+	}
+}
+
+func resourceEnvironmentVariableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	v, err := client.CreateEnvironmentVariable(ctx, expandEnvironmentVariable(d))
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	d.SetId(strconv.Itoa(v.Id))
+	return setEnvironmentVariable(d, v)
+}
+
+func resourceEnvironmentVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	id := d.Id()
+	data, err := client.ReadEnvironmentVariable(ctx, id)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Environment variable %v not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setEnvironmentVariable(d, data)
+}
+
+func setEnvironmentVariable(d *schema.ResourceData, data *buddyResponseEnvironmentVariable) diag.Diagnostics {
+	if diags := setVariable(d, data.buddyResponseVariable); diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("project", data.Project.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("environment_id", data.Environment.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceEnvironmentVariableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	id := d.Id()
+	v, err := client.UpdateEnvironmentVariable(ctx, id, expandEnvironmentVariable(d))
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setEnvironmentVariable(d, v)
+}
+
+func resourceEnvironmentVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	id := d.Id()
+	err := client.DeleteVariable(ctx, id)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+	return nil
+}
+
+func expandEnvironmentVariable(d *schema.ResourceData) buddyRequestEnvironmentVariable {
+	return buddyRequestEnvironmentVariable{
+		Key:         d.Get("key").(string),
+		Value:       d.Get("value").(string),
+		Type:        d.Get("type").(string),
+		Description: d.Get("description").(string),
+		Settable:    d.Get("settable").(bool),
+		Encrypted:   d.Get("encrypted").(bool),
+		Project: buddyRequestProject{
+			Name: d.Get("project").(string),
+		},
+		Environment: buddyRequestEnvironment{
+			Id: d.Get("environment_id").(string),
+		},
+
+		buddyRequestVariableFile: expandVariableFile(d),
+	}
+}
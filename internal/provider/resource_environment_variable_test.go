@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceEnvironmentVariable(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	environmentId := fake.addEnvironment("my-project", "Production")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVariableDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceEnvironmentVariableConfig(fake, environmentId, "staging", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_environment_variable.test", "key", "ENVIRONMENT"),
+					resource.TestCheckResourceAttr("buddy_environment_variable.test", "project", "my-project"),
+					resource.TestCheckResourceAttr("buddy_environment_variable.test", "environment_id", environmentId),
+					resource.TestCheckResourceAttr("buddy_environment_variable.test", "settable", "true"),
+					resource.TestCheckResourceAttr("buddy_environment_variable.test", "value", "staging"),
+				),
+			},
+			{
+				Config: testAccResourceEnvironmentVariableConfig(fake, environmentId, "production", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_environment_variable.test", "settable", "false"),
+					resource.TestCheckResourceAttr("buddy_environment_variable.test", "value", "production"),
+				),
+			},
+			{
+				ResourceName:            "buddy_environment_variable.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"type"},
+			},
+		},
+	})
+}
+
+func TestAccResourceEnvironmentVariable_missingEnvironment(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceEnvironmentVariableConfig(fake, "missing", "staging", false),
+				ExpectError: regexp.MustCompile("Environment not found"),
+			},
+		},
+	})
+}
+
+func testAccResourceEnvironmentVariableConfig(fake *fakeBuddy, environmentId string, value string, settable bool) string {
+	return fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_environment_variable" "test" {
+  project        = "my-project"
+  environment_id = %q
+  key            = "ENVIRONMENT"
+  value          = %q
+  settable       = %t
+}
+`, environmentId, value, settable)
+}
+
+func TestAccResourceEnvironmentVariable_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	environmentId := fake.addEnvironment("my-project", "Production")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVariableDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceEnvironmentVariableConfig(fake, environmentId, "staging", false),
+				Check: testAccCheckResourceDisappears("buddy_environment_variable.test", func(id string) error {
+					variableId, err := strconv.Atoi(id)
+					if err != nil {
+						return err
+					}
+					fake.removeVariable(variableId)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
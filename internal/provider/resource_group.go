@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_group` manages group of members in a Buddy workspace.\n\n" +
+			"Group gives the same access to all of its members, " +
+			"and can be added automatically to new projects with the given permission set.",
+
+		CreateContext: resourceGroupCreate,
+		ReadContext:   resourceGroupRead,
+		UpdateContext: resourceGroupUpdate,
+		DeleteContext: resourceGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: groupCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Group name",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Group description",
+			},
+			"auto_assign_to_new_projects": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Add the group to every new project of the workspace",
+			},
+			"auto_assign_permission_set_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "ID of the permission set granted to the group in new projects. Required when `auto_assign_to_new_projects` is `true`",
+			},
+			"html_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Group URL in the Buddy web interface",
+			},
+		},
+	}
+}
+
+func groupCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("auto_assign_to_new_projects") || !d.NewValueKnown("auto_assign_permission_set_id") {
+		return nil
+	}
+
+	if d.Get("auto_assign_to_new_projects").(bool) && d.Get("auto_assign_permission_set_id").(int) == 0 {
+		return fmt.Errorf("auto_assign_permission_set_id is required when auto_assign_to_new_projects is true")
+	}
+
+	return nil
+}
+
+func expandGroup(d *schema.ResourceData) buddyRequestGroup {
+	return buddyRequestGroup{
+		Name:                      d.Get("name").(string),
+		Description:               d.Get("description").(string),
+		AutoAssignToNewProjects:   d.Get("auto_assign_to_new_projects").(bool),
+		AutoAssignPermissionSetId: d.Get("auto_assign_permission_set_id").(int),
+	}
+}
+
+func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	group, err := client.CreateGroup(ctx, expandGroup(d))
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	d.SetId(strconv.Itoa(group.Id))
+	return setGroup(d, group)
+}
+
+func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	id := d.Id()
+
+	group, err := client.ReadGroup(ctx, id)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Group %v not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setGroup(d, group)
+}
+
+func setGroup(d *schema.ResourceData, group *buddyResponseGroup) diag.Diagnostics {
+	if err := d.Set("name", group.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("description", group.Description); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("auto_assign_to_new_projects", group.AutoAssignToNewProjects); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("auto_assign_permission_set_id", group.AutoAssignPermissionSetId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("html_url", group.HTMLURL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	group, err := client.UpdateGroup(ctx, d.Id(), expandGroup(d))
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setGroup(d, group)
+}
+
+func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	err := client.DeleteGroup(ctx, d.Id())
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return nil
+}
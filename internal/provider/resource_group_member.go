@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGroupMember() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_group_member` manages member of a Buddy group.\n\n" +
+			"Member is added to a group using their ID and gets the access granted to the group.",
+
+		CreateContext: resourceGroupMemberCreate,
+		ReadContext:   resourceGroupMemberRead,
+		DeleteContext: resourceGroupMemberDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Group ID",
+			},
+			"member_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Member ID",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Email address of the member",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Member name",
+			},
+		},
+	}
+}
+
+func resourceGroupMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	groupId := d.Get("group_id").(string)
+	memberId := d.Get("member_id").(string)
+
+	member, err := client.CreateGroupMember(ctx, groupId, memberId)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	id := fmt.Sprintf("%v:%v", groupId, memberId)
+
+	d.SetId(id)
+	return setGroupMember(d, groupId, member)
+}
+
+func resourceGroupMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	ids := strings.Split(d.Id(), ":")
+	if len(ids) != 2 {
+		return diag.Errorf("Unexpected format of ID (%v), expected group_id:member_id", d.Id())
+	}
+
+	member, err := client.ReadGroupMember(ctx, ids[0], ids[1])
+	if IsNotFound(err) {
+		log.Printf("[WARN] Group member %v not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setGroupMember(d, ids[0], member)
+}
+
+func setGroupMember(d *schema.ResourceData, groupId string, member *buddyResponseWorkspaceMember) diag.Diagnostics {
+	if err := d.Set("group_id", groupId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("member_id", strconv.Itoa(member.Id)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("email", member.Email); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", member.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGroupMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	ids := strings.Split(d.Id(), ":")
+
+	err := client.DeleteGroupMember(ctx, ids[0], ids[1])
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return nil
+}
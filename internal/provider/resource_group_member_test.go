@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceGroupMember(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addMember("jane@example.com", "Jane Doe")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGroupMemberDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupMemberConfig(fake),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("buddy_group_member.test", "group_id", "buddy_group.test", "id"),
+					resource.TestCheckResourceAttrPair("buddy_group_member.test", "member_id", "data.buddy_workspace_member.jane", "id"),
+					resource.TestCheckResourceAttr("buddy_group_member.test", "email", "jane@example.com"),
+					resource.TestCheckResourceAttr("buddy_group_member.test", "name", "Jane Doe"),
+				),
+			},
+			{
+				ResourceName:      "buddy_group_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceGroupMember_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addMember("jane@example.com", "Jane Doe")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGroupMemberDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupMemberConfig(fake),
+				Check: testAccCheckResourceDisappears("buddy_group_member.test", func(id string) error {
+					groupId, memberId, err := parseGroupMemberId(id)
+					if err != nil {
+						return err
+					}
+					fake.removeGroupMember(groupId, memberId)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceGroupMemberConfig(fake *fakeBuddy) string {
+	return fake.providerConfig() + `
+data "buddy_workspace_member" "jane" {
+  email = "jane@example.com"
+}
+
+resource "buddy_group" "test" {
+  name = "Developers"
+}
+
+resource "buddy_group_member" "test" {
+  group_id  = buddy_group.test.id
+  member_id = data.buddy_workspace_member.jane.id
+}
+`
+}
+
+func parseGroupMemberId(id string) (int, int, error) {
+	ids := strings.Split(id, ":")
+	if len(ids) != 2 {
+		return 0, 0, fmt.Errorf("unexpected group member ID %v", id)
+	}
+
+	groupId, err := strconv.Atoi(ids[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	memberId, err := strconv.Atoi(ids[1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return groupId, memberId, nil
+}
+
+func testAccCheckGroupMemberDestroy(fake *fakeBuddy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "buddy_group_member" {
+				continue
+			}
+
+			groupId, memberId, err := parseGroupMemberId(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			if fake.hasGroupMember(groupId, memberId) {
+				return fmt.Errorf("group member %v still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceGroup(t *testing.T) {
+	fake := newFakeBuddy(t)
+	developer := fake.permissionSetByName("Developer")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGroupDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_group" "test" {
+  name = "Developers"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_group.test", "name", "Developers"),
+					resource.TestCheckResourceAttr("buddy_group.test", "auto_assign_to_new_projects", "false"),
+					resource.TestCheckResourceAttrSet("buddy_group.test", "html_url"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_group" "test" {
+  name                          = "Backend developers"
+  description                   = "Backend team"
+  auto_assign_to_new_projects   = true
+  auto_assign_permission_set_id = %v
+}
+`, developer.Id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_group.test", "name", "Backend developers"),
+					resource.TestCheckResourceAttr("buddy_group.test", "description", "Backend team"),
+					resource.TestCheckResourceAttr("buddy_group.test", "auto_assign_to_new_projects", "true"),
+					resource.TestCheckResourceAttr("buddy_group.test", "auto_assign_permission_set_id", strconv.Itoa(developer.Id)),
+				),
+			},
+			{
+				ResourceName:      "buddy_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceGroup_autoAssignRequiresPermissionSet(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_group" "test" {
+  name                        = "Developers"
+  auto_assign_to_new_projects = true
+}
+`,
+				ExpectError: regexp.MustCompile("auto_assign_permission_set_id is required"),
+			},
+		},
+	})
+}
+
+func TestAccResourceGroup_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckGroupDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_group" "test" {
+  name = "Developers"
+}
+`,
+				Check: testAccCheckResourceDisappears("buddy_group.test", func(id string) error {
+					groupId, err := strconv.Atoi(id)
+					if err != nil {
+						return err
+					}
+					fake.removeGroup(groupId)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckGroupDestroy(fake *fakeBuddy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "buddy_group" {
+				continue
+			}
+
+			id, err := strconv.Atoi(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			if fake.hasGroup(id) {
+				return fmt.Errorf("group %v still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	pipelineAccessLevels   = []string{"DENIED", "READ_ONLY", "RUN_ONLY", "READ_WRITE"}
+	repositoryAccessLevels = []string{"DENIED", "READ_ONLY", "READ_WRITE", "MANAGE"}
+	sandboxAccessLevels    = []string{"DENIED", "READ_ONLY", "READ_WRITE"}
+)
+
+func resourcePermissionSet() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_permission_set` manages custom permission set in a Buddy workspace.\n\n" +
+			"Permission set defines the access level to pipelines, repository and sandboxes " +
+			"granted to project members and groups.",
+
+		CreateContext: resourcePermissionSetCreate,
+		ReadContext:   resourcePermissionSetRead,
+		UpdateContext: resourcePermissionSetUpdate,
+		DeleteContext: resourcePermissionSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Permission set name",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Permission set description",
+			},
+			"pipeline_access_level": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(pipelineAccessLevels, false),
+				Description:  "Access level to pipelines. Valid values are `DENIED`, `READ_ONLY`, `RUN_ONLY` and `READ_WRITE`",
+			},
+			"repository_access_level": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(repositoryAccessLevels, false),
+				Description:  "Access level to the repository. Valid values are `DENIED`, `READ_ONLY`, `READ_WRITE` and `MANAGE`",
+			},
+			"sandbox_access_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DENIED",
+				ValidateFunc: validation.StringInSlice(sandboxAccessLevels, false),
+				Description:  "Access level to sandboxes. Valid values are `DENIED`, `READ_ONLY` and `READ_WRITE`",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Permission set type. Always `CUSTOM` for permission sets managed by this resource",
+			},
+			"html_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Permission set URL in the Buddy web interface",
+			},
+		},
+	}
+}
+
+func expandPermissionSet(d *schema.ResourceData) buddyRequestCustomPermissionSet {
+	return buddyRequestCustomPermissionSet{
+		Name:                  d.Get("name").(string),
+		Description:           d.Get("description").(string),
+		PipelineAccessLevel:   d.Get("pipeline_access_level").(string),
+		RepositoryAccessLevel: d.Get("repository_access_level").(string),
+		SandboxAccessLevel:    d.Get("sandbox_access_level").(string),
+	}
+}
+
+func resourcePermissionSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	permissionSet, err := client.CreatePermissionSet(ctx, expandPermissionSet(d))
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	d.SetId(strconv.Itoa(permissionSet.Id))
+	return setPermissionSet(d, permissionSet)
+}
+
+func resourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	id := d.Id()
+
+	permissionSet, err := client.ReadPermissionSet(ctx, id)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Permission set %v not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setPermissionSet(d, permissionSet)
+}
+
+func setPermissionSet(d *schema.ResourceData, permissionSet *buddyPermissionSet) diag.Diagnostics {
+	if err := d.Set("name", permissionSet.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("description", permissionSet.Description); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("pipeline_access_level", permissionSet.PipelineAccessLevel); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("repository_access_level", permissionSet.RepositoryAccessLevel); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("sandbox_access_level", permissionSet.SandboxAccessLevel); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("type", permissionSet.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("html_url", permissionSet.HTMLURL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePermissionSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	permissionSet, err := client.UpdatePermissionSet(ctx, d.Id(), expandPermissionSet(d))
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setPermissionSet(d, permissionSet)
+}
+
+func resourcePermissionSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	err := client.DeletePermissionSet(ctx, d.Id())
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourcePermissionSet(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckPermissionSetDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePermissionSetConfig(fake, "RUN_ONLY", "DENIED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_permission_set.test", "name", "Release managers"),
+					resource.TestCheckResourceAttr("buddy_permission_set.test", "pipeline_access_level", "RUN_ONLY"),
+					resource.TestCheckResourceAttr("buddy_permission_set.test", "repository_access_level", "READ_ONLY"),
+					resource.TestCheckResourceAttr("buddy_permission_set.test", "sandbox_access_level", "DENIED"),
+					resource.TestCheckResourceAttr("buddy_permission_set.test", "type", "CUSTOM"),
+					resource.TestCheckResourceAttrSet("buddy_permission_set.test", "html_url"),
+				),
+			},
+			{
+				Config: testAccResourcePermissionSetConfig(fake, "READ_WRITE", "READ_ONLY"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_permission_set.test", "pipeline_access_level", "READ_WRITE"),
+					resource.TestCheckResourceAttr("buddy_permission_set.test", "sandbox_access_level", "READ_ONLY"),
+				),
+			},
+			{
+				ResourceName:      "buddy_permission_set.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourcePermissionSet_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckPermissionSetDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePermissionSetConfig(fake, "RUN_ONLY", "DENIED"),
+				Check: testAccCheckResourceDisappears("buddy_permission_set.test", func(id string) error {
+					permissionSetId, err := strconv.Atoi(id)
+					if err != nil {
+						return err
+					}
+					fake.removePermissionSet(permissionSetId)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourcePermissionSetConfig(fake *fakeBuddy, pipelineAccessLevel string, sandboxAccessLevel string) string {
+	return fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_permission_set" "test" {
+  name                    = "Release managers"
+  description             = "Can run the release pipelines"
+  pipeline_access_level   = "%v"
+  repository_access_level = "READ_ONLY"
+  sandbox_access_level    = "%v"
+}
+`, pipelineAccessLevel, sandboxAccessLevel)
+}
+
+func testAccCheckPermissionSetDestroy(fake *fakeBuddy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "buddy_permission_set" {
+				continue
+			}
+
+			id, err := strconv.Atoi(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			if fake.hasPermissionSet(id) {
+				return fmt.Errorf("permission set %v still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,462 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePipeline() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_pipeline` manages pipeline on a Buddy project.\n\n" +
+			"Pipeline can be run manually, triggered by repository events or run on a schedule. " +
+			"Use `buddy_pipeline_action` to manage the actions executed by the pipeline.",
+
+		CreateContext: resourcePipelineCreate,
+		ReadContext:   resourcePipelineRead,
+		UpdateContext: resourcePipelineUpdate,
+		DeleteContext: resourcePipelineDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: pipelineCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project name",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Pipeline name",
+			},
+			"on": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"CLICK", "EVENT", "SCHEDULE"}, false),
+				Description:  "Pipeline trigger mode. Valid values are `CLICK`, `EVENT` and `SCHEDULE`",
+			},
+			"refs": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Branches or tags the pipeline runs on, e.g. `refs/heads/master`. Used with `CLICK` and `SCHEDULE`",
+			},
+			"event": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Repository events triggering the pipeline. Used with `EVENT`",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"PUSH", "CREATE_REF", "DELETE_REF"}, false),
+							Description:  "Event type. Valid values are `PUSH`, `CREATE_REF` and `DELETE_REF`",
+						},
+						"refs": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Branches or tags the event applies to, wildcards are allowed",
+						},
+					},
+				},
+			},
+			"trigger_condition": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Conditions that have to be met for the pipeline to run",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"condition": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Condition type, e.g. `ON_CHANGE`, `ON_CHANGE_AT_PATH`, `VAR_IS`, `DATETIME` or `SUCCESS_PIPELINE`",
+						},
+						"paths": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Paths checked by `ON_CHANGE_AT_PATH`",
+						},
+						"variable_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Variable checked by the `VAR_*` conditions",
+						},
+						"variable_value": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Value compared by the `VAR_*` conditions",
+						},
+						"hours": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "Hours of the day checked by `DATETIME`",
+						},
+						"days": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "Days of the week checked by `DATETIME`, 1 is Monday",
+						},
+						"zone_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Time zone used by `DATETIME`, e.g. `Europe/Warsaw`",
+						},
+						"project_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Project of the pipeline checked by `SUCCESS_PIPELINE`",
+						},
+						"pipeline_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Pipeline checked by `SUCCESS_PIPELINE`",
+						},
+					},
+				},
+			},
+			"cron": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Cron expression of the schedule. Used with `SCHEDULE`",
+			},
+			"delay": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Interval in minutes between scheduled runs. Used with `SCHEDULE` and `start_date` when `cron` is not set",
+			},
+			"start_date": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Date of the first scheduled run in ISO 8601 format. Used with `SCHEDULE`",
+			},
+			"priority": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "NORMAL",
+				ValidateFunc: validation.StringInSlice([]string{"LOW", "NORMAL", "HIGH"}, false),
+				Description:  "Pipeline priority in the execution queue. Valid values are `LOW`, `NORMAL` and `HIGH`",
+			},
+			"execution_message_template": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Template of the run title",
+			},
+			"target_site_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the site deployed by the pipeline",
+			},
+			"always_from_scratch": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Flag to decide whether all files are uploaded on every run instead of only the changed ones",
+			},
+			"fail_on_prepare_env_warning": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Flag to decide whether the run fails when preparing the environment produces warnings",
+			},
+			"auto_clear_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Flag to decide whether the pipeline cache is cleared before every run",
+			},
+			"no_skip_to_most_recent": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Flag to decide whether queued runs are executed instead of skipping to the most recent one",
+			},
+			"do_not_create_commit_status": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Flag to decide whether the commit status is not reported to the repository",
+			},
+			"ignore_fail_on_project_status": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Flag to decide whether a failed run does not affect the project status",
+			},
+			"paused": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Flag to decide whether the pipeline is paused",
+			},
+			"pipeline_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Pipeline ID",
+			},
+			"html_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Pipeline URL in the Buddy web interface",
+			},
+			"last_execution_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the last pipeline run",
+			},
+			"create_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Pipeline creation date",
+			},
+		},
+	}
+}
+
+// pipelineCustomizeDiff rejects a schedule on pipelines that aren't run on a schedule,
+// as Buddy doesn't use it there
+func pipelineCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("on") || d.Get("on").(string) == "SCHEDULE" {
+		return nil
+	}
+
+	for _, key := range []string{"cron", "delay", "start_date"} {
+		if _, ok := d.GetOk(key); ok {
+			return fmt.Errorf("%v can only be set when on is SCHEDULE", key)
+		}
+	}
+
+	return nil
+}
+
+func resourcePipelineCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	projectName := d.Get("project_name").(string)
+	pipeline := expandPipeline(d)
+
+	p, err := client.CreatePipeline(ctx, projectName, pipeline)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	id := fmt.Sprintf("%v:%v", projectName, p.Id)
+
+	d.SetId(id)
+	return setPipeline(d, projectName, p)
+}
+
+func resourcePipelineRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	ids := strings.Split(d.Id(), ":")
+	if len(ids) != 2 {
+		return diag.Errorf("Unexpected format of ID (%v), expected project_name:pipeline_id", d.Id())
+	}
+
+	pipeline, err := client.ReadPipeline(ctx, ids[0], ids[1])
+	if IsNotFound(err) {
+		log.Printf("[WARN] Pipeline %v not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setPipeline(d, ids[0], pipeline)
+}
+
+func setPipeline(d *schema.ResourceData, projectName string, pipeline *buddyResponsePipeline) diag.Diagnostics {
+	values := map[string]interface{}{
+		"project_name":                  projectName,
+		"name":                          pipeline.Name,
+		"on":                            pipeline.On,
+		"refs":                          pipeline.Refs,
+		"event":                         flattenPipelineEvents(pipeline.Events),
+		"trigger_condition":             flattenPipelineTriggerConditions(pipeline.TriggerConditions),
+		"priority":                      pipeline.Priority,
+		"execution_message_template":    pipeline.ExecutionMessageTemplate,
+		"target_site_url":               pipeline.TargetSiteUrl,
+		"always_from_scratch":           pipeline.AlwaysFromScratch,
+		"fail_on_prepare_env_warning":   pipeline.FailOnPrepareEnvWarning,
+		"auto_clear_cache":              pipeline.AutoClearCache,
+		"no_skip_to_most_recent":        pipeline.NoSkipToMostRecent,
+		"do_not_create_commit_status":   pipeline.DoNotCreateCommitStatus,
+		"ignore_fail_on_project_status": pipeline.IgnoreFailOnProjectStatus,
+		"paused":                        pipeline.Paused,
+		"pipeline_id":                   pipeline.Id,
+		"html_url":                      pipeline.HTMLURL,
+		"last_execution_status":         pipeline.LastExecutionStatus,
+		"create_date":                   pipeline.CreateDate,
+	}
+
+	// Buddy may keep the schedule of a pipeline switched to another trigger mode, where it's unused
+	values["cron"], values["delay"], values["start_date"] = "", 0, ""
+	if pipeline.On == "SCHEDULE" {
+		values["cron"], values["delay"], values["start_date"] = pipeline.Cron, pipeline.Delay, pipeline.StartDate
+	}
+
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourcePipelineUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	ids := strings.Split(d.Id(), ":")
+	if len(ids) != 2 {
+		return diag.Errorf("Unexpected format of ID (%v), expected project_name:pipeline_id", d.Id())
+	}
+	pipeline := expandPipeline(d)
+
+	p, err := client.UpdatePipeline(ctx, ids[0], ids[1], pipeline)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setPipeline(d, ids[0], p)
+}
+
+func resourcePipelineDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	ids := strings.Split(d.Id(), ":")
+	if len(ids) != 2 {
+		return diag.Errorf("Unexpected format of ID (%v), expected project_name:pipeline_id", d.Id())
+	}
+
+	err := client.DeletePipeline(ctx, ids[0], ids[1])
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return nil
+}
+
+func expandPipeline(d *schema.ResourceData) buddyRequestPipeline {
+	pipeline := buddyRequestPipeline{
+		Name:                      d.Get("name").(string),
+		On:                        d.Get("on").(string),
+		Refs:                      expandStringList(d.Get("refs").([]interface{})),
+		Events:                    expandPipelineEvents(d.Get("event").([]interface{})),
+		TriggerConditions:         expandPipelineTriggerConditions(d.Get("trigger_condition").([]interface{})),
+		Priority:                  d.Get("priority").(string),
+		ExecutionMessageTemplate:  d.Get("execution_message_template").(string),
+		TargetSiteUrl:             d.Get("target_site_url").(string),
+		AlwaysFromScratch:         d.Get("always_from_scratch").(bool),
+		FailOnPrepareEnvWarning:   d.Get("fail_on_prepare_env_warning").(bool),
+		AutoClearCache:            d.Get("auto_clear_cache").(bool),
+		NoSkipToMostRecent:        d.Get("no_skip_to_most_recent").(bool),
+		DoNotCreateCommitStatus:   d.Get("do_not_create_commit_status").(bool),
+		IgnoreFailOnProjectStatus: d.Get("ignore_fail_on_project_status").(bool),
+		Paused:                    d.Get("paused").(bool),
+	}
+
+	if pipeline.On == "SCHEDULE" {
+		pipeline.Cron = d.Get("cron").(string)
+		pipeline.Delay = d.Get("delay").(int)
+		pipeline.StartDate = d.Get("start_date").(string)
+	}
+
+	return pipeline
+}
+
+func expandPipelineEvents(raw []interface{}) []buddyPipelineEvent {
+	events := make([]buddyPipelineEvent, 0, len(raw))
+	for _, r := range raw {
+		event := r.(map[string]interface{})
+		events = append(events, buddyPipelineEvent{
+			Type: event["type"].(string),
+			Refs: expandStringList(event["refs"].([]interface{})),
+		})
+	}
+
+	return events
+}
+
+func flattenPipelineEvents(events []buddyPipelineEvent) []interface{} {
+	result := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		result = append(result, map[string]interface{}{
+			"type": event.Type,
+			"refs": event.Refs,
+		})
+	}
+
+	return result
+}
+
+func expandPipelineTriggerConditions(raw []interface{}) []buddyPipelineTriggerCondition {
+	conditions := make([]buddyPipelineTriggerCondition, 0, len(raw))
+	for _, r := range raw {
+		condition := r.(map[string]interface{})
+		conditions = append(conditions, buddyPipelineTriggerCondition{
+			TriggerCondition:      condition["condition"].(string),
+			TriggerConditionPaths: expandStringList(condition["paths"].([]interface{})),
+			TriggerVariableKey:    condition["variable_key"].(string),
+			TriggerVariableValue:  condition["variable_value"].(string),
+			TriggerHours:          expandIntList(condition["hours"].([]interface{})),
+			TriggerDays:           expandIntList(condition["days"].([]interface{})),
+			ZoneId:                condition["zone_id"].(string),
+			TriggerProjectName:    condition["project_name"].(string),
+			TriggerPipelineName:   condition["pipeline_name"].(string),
+		})
+	}
+
+	return conditions
+}
+
+func flattenPipelineTriggerConditions(conditions []buddyPipelineTriggerCondition) []interface{} {
+	result := make([]interface{}, 0, len(conditions))
+	for _, condition := range conditions {
+		result = append(result, map[string]interface{}{
+			"condition":      condition.TriggerCondition,
+			"paths":          condition.TriggerConditionPaths,
+			"variable_key":   condition.TriggerVariableKey,
+			"variable_value": condition.TriggerVariableValue,
+			"hours":          condition.TriggerHours,
+			"days":           condition.TriggerDays,
+			"zone_id":        condition.ZoneId,
+			"project_name":   condition.TriggerProjectName,
+			"pipeline_name":  condition.TriggerPipelineName,
+		})
+	}
+
+	return result
+}
+
+func expandStringList(raw []interface{}) []string {
+	result := make([]string, 0, len(raw))
+	for _, v := range raw {
+		result = append(result, v.(string))
+	}
+
+	return result
+}
+
+func expandIntList(raw []interface{}) []int {
+	result := make([]int, 0, len(raw))
+	for _, v := range raw {
+		result = append(result, v.(int))
+	}
+
+	return result
+}
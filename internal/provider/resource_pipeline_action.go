@@ -0,0 +1,416 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// pipelineActionTypedFields are the action fields managed by dedicated attributes,
+// so they are not allowed in settings_json
+var pipelineActionTypedFields = []string{
+	"name",
+	"type",
+	"trigger_time",
+	"run_only_on_first_failure",
+	"execute_commands",
+	"docker_image_name",
+	"docker_image_tag",
+	"variables",
+	"after_action_id",
+}
+
+func resourcePipelineAction() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_pipeline_action` manages action executed by a Buddy pipeline.\n\n" +
+			"Common action fields are exposed as attributes. " +
+			"Fields specific to an action type can be set through `settings_json`.",
+
+		CreateContext: resourcePipelineActionCreate,
+		ReadContext:   resourcePipelineActionRead,
+		UpdateContext: resourcePipelineActionUpdate,
+		DeleteContext: resourcePipelineActionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project name",
+			},
+			"pipeline_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Pipeline ID",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Action name",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Action type, e.g. `BUILD`, `SSH_COMMAND`, `DOCKERFILE`, `HTTP` or `SLACK`",
+			},
+			"trigger_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ON_EVERY_EXECUTION",
+				ValidateFunc: validation.StringInSlice([]string{"ON_EVERY_EXECUTION", "ON_FAILURE", "ON_BACK_TO_SUCCESS", "ON_WARNING"}, false),
+				Description:  "When the action is executed. Valid values are `ON_EVERY_EXECUTION`, `ON_FAILURE`, `ON_BACK_TO_SUCCESS` and `ON_WARNING`",
+			},
+			"run_only_on_first_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Flag to decide whether an `ON_FAILURE` action is executed only on the first failed run",
+			},
+			"execute_commands": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Commands executed by the action",
+			},
+			"docker_image_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Docker image the commands are executed in",
+			},
+			"docker_image_tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Tag of the Docker image the commands are executed in",
+			},
+			"variable": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Variables available only to this action",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Variable name",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "Variable value",
+						},
+						"settable": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Flag to decide whether the variable is settable by pipeline run",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Variable description",
+						},
+					},
+				},
+			},
+			"settings_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validatePipelineActionSettings,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				Description: "JSON object with fields specific to the action type, e.g. `{\"endpoint\": \"https://example.com\"}` for `HTTP`. " +
+					"Only the fields set here are tracked for changes",
+			},
+			"after_action_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "ID of the action after which this action is placed. The action is appended to the end of the pipeline when not set",
+			},
+			"position": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Position of the action in the pipeline, starting from 1",
+			},
+			"action_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Action ID",
+			},
+			"html_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Action URL in the Buddy web interface",
+			},
+		},
+	}
+}
+
+func resourcePipelineActionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	projectName := d.Get("project_name").(string)
+	pipelineId := strconv.Itoa(d.Get("pipeline_id").(int))
+	action, err := expandPipelineAction(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	action.AfterActionId = d.Get("after_action_id").(int)
+
+	a, err := client.CreatePipelineAction(ctx, projectName, pipelineId, action)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	id := fmt.Sprintf("%v:%v:%v", projectName, pipelineId, a.Id)
+	d.SetId(id)
+
+	// Buddy doesn't return the position of the action, so it is looked up from the pipeline
+	position, afterActionId, err := pipelineActionPosition(ctx, client, projectName, pipelineId, a.Id)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setPipelineAction(d, projectName, d.Get("pipeline_id").(int), a, position, afterActionId)
+}
+
+func resourcePipelineActionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	ids := strings.Split(d.Id(), ":")
+	if len(ids) != 3 {
+		return diag.Errorf("Unexpected format of ID (%v), expected project_name:pipeline_id:action_id", d.Id())
+	}
+
+	action, err := client.ReadPipelineAction(ctx, ids[0], ids[1], ids[2])
+	if IsNotFound(err) {
+		log.Printf("[WARN] Pipeline action %v not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	position, afterActionId, err := pipelineActionPosition(ctx, client, ids[0], ids[1], action.Id)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	pipelineId, err := strconv.Atoi(ids[1])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setPipelineAction(d, ids[0], pipelineId, action, position, afterActionId)
+}
+
+func setPipelineAction(d *schema.ResourceData, projectName string, pipelineId int, action *buddyResponsePipelineAction, position int, afterActionId int) diag.Diagnostics {
+	settings, err := flattenPipelineActionSettings(d.Get("settings_json").(string), action.Raw)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"project_name":              projectName,
+		"pipeline_id":               pipelineId,
+		"name":                      action.Name,
+		"type":                      action.Type,
+		"trigger_time":              action.TriggerTime,
+		"run_only_on_first_failure": action.RunOnlyOnFirstFailure,
+		"execute_commands":          action.ExecuteCommands,
+		"docker_image_name":         action.DockerImageName,
+		"docker_image_tag":          action.DockerImageTag,
+		"variable":                  flattenActionVariables(action.Variables),
+		"settings_json":             settings,
+		"after_action_id":           afterActionId,
+		"position":                  position,
+		"action_id":                 action.Id,
+		"html_url":                  action.HTMLURL,
+	}
+
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourcePipelineActionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	ids := strings.Split(d.Id(), ":")
+	if len(ids) != 3 {
+		return diag.Errorf("Unexpected format of ID (%v), expected project_name:pipeline_id:action_id", d.Id())
+	}
+
+	action, err := expandPipelineAction(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	a, err := client.UpdatePipelineAction(ctx, ids[0], ids[1], ids[2], action)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	// Updating an action doesn't move it, so the position known from the last read is kept
+	return setPipelineAction(d, ids[0], d.Get("pipeline_id").(int), a, d.Get("position").(int), d.Get("after_action_id").(int))
+}
+
+func resourcePipelineActionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	ids := strings.Split(d.Id(), ":")
+	if len(ids) != 3 {
+		return diag.Errorf("Unexpected format of ID (%v), expected project_name:pipeline_id:action_id", d.Id())
+	}
+
+	err := client.DeletePipelineAction(ctx, ids[0], ids[1], ids[2])
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return nil
+}
+
+// pipelineActionPosition returns the position of the action in the pipeline, starting from 1,
+// and the ID of the action placed before it
+func pipelineActionPosition(ctx context.Context, client buddyClient, projectName string, pipelineId string, actionId int) (int, int, error) {
+	actions, err := client.ListPipelineActions(ctx, projectName, pipelineId)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i, a := range actions {
+		if a.Id == actionId {
+			if i > 0 {
+				return i + 1, actions[i-1].Id, nil
+			}
+			return i + 1, 0, nil
+		}
+	}
+
+	return 0, 0, nil
+}
+
+func expandPipelineAction(d *schema.ResourceData) (buddyRequestPipelineAction, error) {
+	action := buddyRequestPipelineAction{
+		Name:                  d.Get("name").(string),
+		Type:                  d.Get("type").(string),
+		TriggerTime:           d.Get("trigger_time").(string),
+		RunOnlyOnFirstFailure: d.Get("run_only_on_first_failure").(bool),
+		ExecuteCommands:       expandStringList(d.Get("execute_commands").([]interface{})),
+		DockerImageName:       d.Get("docker_image_name").(string),
+		DockerImageTag:        d.Get("docker_image_tag").(string),
+		Variables:             expandActionVariables(d.Get("variable").([]interface{})),
+	}
+
+	settingsJson := d.Get("settings_json").(string)
+	if settingsJson == "" {
+		return action, nil
+	}
+
+	settings, err := structure.ExpandJsonFromString(settingsJson)
+	if err != nil {
+		return action, err
+	}
+
+	action.Settings = settings
+	return action, nil
+}
+
+// validatePipelineActionSettings checks that settings_json is a JSON object without the
+// fields managed by dedicated attributes
+func validatePipelineActionSettings(i interface{}, k string) ([]string, []error) {
+	warnings, errs := validation.StringIsJSON(i, k)
+	if len(errs) > 0 {
+		return warnings, errs
+	}
+
+	settings, err := structure.ExpandJsonFromString(i.(string))
+	if err != nil {
+		return warnings, []error{fmt.Errorf("expected %q to be a JSON object: %v", k, err)}
+	}
+
+	for _, field := range pipelineActionTypedFields {
+		if _, ok := settings[field]; ok {
+			errs = append(errs, fmt.Errorf("%v must not contain %v, use the dedicated attribute instead", k, field))
+		}
+	}
+
+	return warnings, errs
+}
+
+// flattenPipelineActionSettings picks the fields present in the configured
+// settings from the action returned by Buddy, so fields managed by Buddy
+// itself don't show up as a difference.
+func flattenPipelineActionSettings(configured string, raw map[string]interface{}) (string, error) {
+	if configured == "" {
+		return "", nil
+	}
+
+	settings, err := structure.ExpandJsonFromString(configured)
+	if err != nil {
+		return "", err
+	}
+
+	result := map[string]interface{}{}
+	for key := range settings {
+		if value, ok := raw[key]; ok {
+			result[key] = value
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func expandActionVariables(raw []interface{}) []buddyActionVariable {
+	variables := make([]buddyActionVariable, 0, len(raw))
+	for _, r := range raw {
+		variable := r.(map[string]interface{})
+		variables = append(variables, buddyActionVariable{
+			Key:         variable["key"].(string),
+			Value:       variable["value"].(string),
+			Type:        "VAR",
+			Settable:    variable["settable"].(bool),
+			Description: variable["description"].(string),
+		})
+	}
+
+	return variables
+}
+
+func flattenActionVariables(variables []buddyActionVariable) []interface{} {
+	result := make([]interface{}, 0, len(variables))
+	for _, variable := range variables {
+		result = append(result, map[string]interface{}{
+			"key":         variable.Key,
+			"value":       variable.Value,
+			"settable":    variable.Settable,
+			"description": variable.Description,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourcePipelineAction(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckPipelineActionDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePipelineActionConfig(fake, "go test ./...", "https://example.com/hook"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("buddy_pipeline_action.build", "id", regexp.MustCompile(`^my-project:\d+:\d+$`)),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.build", "type", "BUILD"),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.build", "execute_commands.0", "go test ./..."),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.build", "docker_image_name", "library/golang"),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.build", "variable.0.key", "GOFLAGS"),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.build", "position", "1"),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.build", "after_action_id", "0"),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.notify", "trigger_time", "ON_FAILURE"),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.notify", "position", "2"),
+					resource.TestCheckResourceAttrPair("buddy_pipeline_action.notify", "after_action_id", "buddy_pipeline_action.build", "action_id"),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.notify", "settings_json", `{"endpoint":"https://example.com/hook","method":"POST"}`),
+				),
+			},
+			{
+				Config: testAccResourcePipelineActionConfig(fake, "go test -race ./...", "https://example.com/other-hook"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_pipeline_action.build", "execute_commands.0", "go test -race ./..."),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.notify", "settings_json", `{"endpoint":"https://example.com/other-hook","method":"POST"}`),
+				),
+			},
+			{
+				Config: testAccResourcePipelineActionConfig(fake, "", "https://example.com/other-hook"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_pipeline_action.build", "execute_commands.#", "0"),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.build", "docker_image_name", ""),
+					resource.TestCheckResourceAttr("buddy_pipeline_action.build", "docker_image_tag", ""),
+				),
+			},
+			{
+				ResourceName:            "buddy_pipeline_action.notify",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"settings_json"},
+			},
+		},
+	})
+}
+
+func TestAccResourcePipelineAction_typedFieldInSettings(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_pipeline" "test" {
+  project_name = "my-project"
+  name         = "Build"
+  on           = "CLICK"
+}
+
+resource "buddy_pipeline_action" "test" {
+  project_name  = "my-project"
+  pipeline_id   = buddy_pipeline.test.pipeline_id
+  name          = "Run tests"
+  type          = "BUILD"
+  settings_json = jsonencode({ execute_commands = ["make"] })
+}
+`,
+				ExpectError: regexp.MustCompile("settings_json must not contain execute_commands"),
+			},
+		},
+	})
+}
+
+func TestResourcePipelineAction_validateSettings(t *testing.T) {
+	cases := map[string]struct {
+		settings string
+		err      *regexp.Regexp
+	}{
+		"action specific fields": {settings: `{"endpoint": "https://example.com"}`},
+		"typed field":            {settings: `{"execute_commands": ["make"]}`, err: regexp.MustCompile("settings_json must not contain execute_commands")},
+		"not an object":          {settings: `["make"]`, err: regexp.MustCompile("to be a JSON object")},
+		"invalid JSON":           {settings: `{`, err: regexp.MustCompile("contains an invalid JSON")},
+	}
+
+	r := resourcePipelineAction()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"project_name":  "my-project",
+				"pipeline_id":   1,
+				"name":          "Notify",
+				"type":          "HTTP",
+				"settings_json": tc.settings,
+			}))
+			if tc.err == nil && diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if tc.err != nil && (!diags.HasError() || !tc.err.MatchString(diags[0].Summary)) {
+				t.Fatalf("expected error matching %q, got %v", tc.err, diags)
+			}
+		})
+	}
+}
+
+func TestResourcePipelineAction_invalidId(t *testing.T) {
+	fake := newFakeBuddy(t)
+	r := resourcePipelineAction()
+
+	operations := map[string]func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics{
+		"read":   r.ReadContext,
+		"update": r.UpdateContext,
+		"delete": r.DeleteContext,
+	}
+	for name, operation := range operations {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project_name": "my-project", "pipeline_id": 1, "name": "Run tests", "type": "BUILD"})
+			d.SetId("my-project:12345")
+
+			diags := operation(context.Background(), d, fake.client())
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, "expected project_name:pipeline_id:action_id") {
+				t.Fatalf("expected an error about the ID format, got %v", diags)
+			}
+		})
+	}
+}
+
+// testAccResourcePipelineActionConfig leaves the commands and the image out of the build action when command is empty
+func testAccResourcePipelineActionConfig(fake *fakeBuddy, command string, endpoint string) string {
+	buildSettings := ""
+	if command != "" {
+		buildSettings = fmt.Sprintf(`
+  execute_commands  = [%q]
+  docker_image_name = "library/golang"
+  docker_image_tag  = "1.16"
+`, command)
+	}
+
+	return fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_pipeline" "test" {
+  project_name = "my-project"
+  name         = "Build"
+  on           = "CLICK"
+}
+
+resource "buddy_pipeline_action" "build" {
+  project_name      = "my-project"
+  pipeline_id       = buddy_pipeline.test.pipeline_id
+  name              = "Run tests"
+  type              = "BUILD"
+%v
+  variable {
+    key   = "GOFLAGS"
+    value = "-mod=vendor"
+  }
+}
+
+resource "buddy_pipeline_action" "notify" {
+  project_name    = "my-project"
+  pipeline_id     = buddy_pipeline.test.pipeline_id
+  name            = "Notify"
+  type            = "HTTP"
+  trigger_time    = "ON_FAILURE"
+  after_action_id = buddy_pipeline_action.build.action_id
+
+  settings_json = jsonencode({
+    endpoint = %q
+    method   = "POST"
+  })
+}
+`, buildSettings, endpoint)
+}
+
+func testAccCheckPipelineActionDestroy(fake *fakeBuddy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "buddy_pipeline_action" {
+				continue
+			}
+
+			ids := strings.Split(rs.Primary.ID, ":")
+			pipelineId, err := strconv.Atoi(ids[1])
+			if err != nil {
+				return err
+			}
+
+			for _, id := range fake.pipelineActionIds(pipelineId) {
+				if strconv.Itoa(id) == ids[2] {
+					return fmt.Errorf("pipeline action %v still exists", rs.Primary.ID)
+				}
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourcePipeline(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckPipelineDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_pipeline" "test" {
+  project_name = "my-project"
+  name         = "Build"
+  on           = "CLICK"
+  refs         = ["refs/heads/master"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("buddy_pipeline.test", "id", regexp.MustCompile(`^my-project:\d+$`)),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "name", "Build"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "on", "CLICK"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "refs.0", "refs/heads/master"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "priority", "NORMAL"),
+					resource.TestCheckResourceAttrSet("buddy_pipeline.test", "pipeline_id"),
+					resource.TestCheckResourceAttrSet("buddy_pipeline.test", "html_url"),
+					resource.TestCheckResourceAttrSet("buddy_pipeline.test", "execution_message_template"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_pipeline" "test" {
+  project_name        = "my-project"
+  name                = "Build and test"
+  on                  = "EVENT"
+  priority            = "HIGH"
+  always_from_scratch = true
+
+  event {
+    type = "PUSH"
+    refs = ["refs/heads/*"]
+  }
+
+  trigger_condition {
+    condition = "ON_CHANGE_AT_PATH"
+    paths     = ["src/", "go.mod"]
+  }
+
+  trigger_condition {
+    condition = "DATETIME"
+    hours     = [9, 10, 11]
+    days      = [1, 2, 3, 4, 5]
+    zone_id   = "Europe/Warsaw"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "name", "Build and test"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "on", "EVENT"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "priority", "HIGH"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "always_from_scratch", "true"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "event.0.type", "PUSH"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "event.0.refs.0", "refs/heads/*"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "trigger_condition.#", "2"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "trigger_condition.0.paths.1", "go.mod"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "trigger_condition.1.days.#", "5"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_pipeline" "test" {
+  project_name = "my-project"
+  name         = "Nightly"
+  on           = "SCHEDULE"
+  refs         = ["refs/heads/master"]
+  cron         = "0 2 * * *"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "on", "SCHEDULE"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "cron", "0 2 * * *"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "event.#", "0"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "trigger_condition.#", "0"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_pipeline" "test" {
+  project_name = "my-project"
+  name         = "Nightly"
+  on           = "CLICK"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "on", "CLICK"),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "cron", ""),
+					resource.TestCheckResourceAttr("buddy_pipeline.test", "refs.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "buddy_pipeline.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourcePipeline_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckPipelineDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_pipeline" "test" {
+  project_name = "my-project"
+  name         = "Build"
+  on           = "CLICK"
+}
+`,
+				Check: testAccCheckResourceDisappears("buddy_pipeline.test", func(id string) error {
+					pipelineId, err := strconv.Atoi(strings.Split(id, ":")[1])
+					if err != nil {
+						return err
+					}
+					fake.removePipeline(pipelineId)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourcePipeline_scheduleOnlyOnSchedule(t *testing.T) {
+	cases := map[string]struct {
+		config map[string]interface{}
+		err    *regexp.Regexp
+	}{
+		"schedule":        {config: map[string]interface{}{"on": "SCHEDULE", "cron": "0 2 * * *"}},
+		"click":           {config: map[string]interface{}{"on": "CLICK"}},
+		"click with cron": {config: map[string]interface{}{"on": "CLICK", "cron": "0 2 * * *"}, err: regexp.MustCompile("cron can only be set when on is SCHEDULE")},
+		"event with delay": {
+			config: map[string]interface{}{"on": "EVENT", "delay": 60},
+			err:    regexp.MustCompile("delay can only be set when on is SCHEDULE"),
+		},
+	}
+
+	r := resourcePipeline()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"project_name": "my-project",
+				"name":         "Nightly",
+			}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if tc.err == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.err != nil && (err == nil || !tc.err.MatchString(err.Error())) {
+				t.Fatalf("expected error matching %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestResourcePipelineUpdate_switchFromSchedule(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	r := resourcePipeline()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project_name": "my-project", "name": "Nightly", "on": "SCHEDULE", "cron": "0 2 * * *"})
+	if diags := r.CreateContext(context.Background(), d, fake.client()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"project_name": "my-project", "name": "Nightly", "on": "CLICK"})
+	diff, err := r.Diff(context.Background(), d.State(), config, fake.client())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state, diags := r.Apply(context.Background(), d.State(), diff, fake.client())
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if state.Attributes["on"] != "CLICK" || state.Attributes["cron"] != "" {
+		t.Fatalf("expected the unused schedule to be left out of the state, got %v", state.Attributes)
+	}
+
+	diff, err = r.Diff(context.Background(), state, config, fake.client())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !diff.Empty() {
+		t.Fatalf("expected an empty plan, got %v", diff)
+	}
+}
+
+func TestResourcePipeline_invalidId(t *testing.T) {
+	fake := newFakeBuddy(t)
+	r := resourcePipeline()
+
+	operations := map[string]func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics{
+		"read":   r.ReadContext,
+		"update": r.UpdateContext,
+		"delete": r.DeleteContext,
+	}
+	for name, operation := range operations {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project_name": "my-project", "name": "Nightly", "on": "CLICK"})
+			d.SetId("12345")
+
+			diags := operation(context.Background(), d, fake.client())
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, "expected project_name:pipeline_id") {
+				t.Fatalf("expected an error about the ID format, got %v", diags)
+			}
+		})
+	}
+}
+
+func testAccCheckPipelineDestroy(fake *fakeBuddy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "buddy_pipeline" {
+				continue
+			}
+
+			pipelineId, err := strconv.Atoi(strings.Split(rs.Primary.ID, ":")[1])
+			if err != nil {
+				return err
+			}
+
+			if fake.hasPipeline(pipelineId) {
+				return fmt.Errorf("pipeline %v still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePipelineVariable() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_pipeline_variable` manages variable under the pipeline scope.\n\n" +
+			"Pipeline scoped variable is accessible only to the actions of a single pipeline. " +
+			"Use this variable to store value that is specific to one pipeline.",
+
+		CreateContext: resourcePipelineVariableCreate,
+		ReadContext:   resourcePipelineVariableRead,
+		UpdateContext: resourcePipelineVariableUpdate,
+		DeleteContext: resourcePipelineVariableDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: variableCustomizeDiff,
+		Schema: variableSchema(map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project name of the pipeline",
+			},
+			"pipeline_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Pipeline ID where variable will be created",
+			},
+		}),
+	}
+}
+
+func resourcePipelineVariableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	v, err := client.CreatePipelineVariable(ctx, expandPipelineVariable(d))
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	d.SetId(strconv.Itoa(v.Id))
+	return setPipelineVariable(d, v)
+}
+
+func resourcePipelineVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	id := d.Id()
+	data, err := client.ReadPipelineVariable(ctx, id)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Pipeline variable %v not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setPipelineVariable(d, data)
+}
+
+func setPipelineVariable(d *schema.ResourceData, data *buddyResponsePipelineVariable) diag.Diagnostics {
+	if diags := setVariable(d, data.buddyResponseVariable); diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("project", data.Project.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("pipeline_id", data.Pipeline.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePipelineVariableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	id := d.Id()
+	v, err := client.UpdatePipelineVariable(ctx, id, expandPipelineVariable(d))
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setPipelineVariable(d, v)
+}
+
+func resourcePipelineVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	id := d.Id()
+	err := client.DeleteVariable(ctx, id)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+	return nil
+}
+
+func expandPipelineVariable(d *schema.ResourceData) buddyRequestPipelineVariable {
+	return buddyRequestPipelineVariable{
+		Key:         d.Get("key").(string),
+		Value:       d.Get("value").(string),
+		Type:        d.Get("type").(string),
+		Description: d.Get("description").(string),
+		Settable:    d.Get("settable").(bool),
+		Encrypted:   d.Get("encrypted").(bool),
+		Project: buddyRequestProject{
+			Name: d.Get("project").(string),
+		},
+		Pipeline: buddyId{
+			Id: d.Get("pipeline_id").(int),
+		},
+
+		buddyRequestVariableFile: expandVariableFile(d),
+	}
+}
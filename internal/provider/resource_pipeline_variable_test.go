@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourcePipelineVariable(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVariableDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePipelineVariableConfig(fake, "staging", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_pipeline_variable.test", "key", "ENVIRONMENT"),
+					resource.TestCheckResourceAttr("buddy_pipeline_variable.test", "project", "my-project"),
+					resource.TestCheckResourceAttrPair("buddy_pipeline_variable.test", "pipeline_id", "buddy_pipeline.test", "pipeline_id"),
+					resource.TestCheckResourceAttr("buddy_pipeline_variable.test", "settable", "true"),
+					resource.TestCheckResourceAttr("buddy_pipeline_variable.test", "value", "staging"),
+				),
+			},
+			{
+				Config: testAccResourcePipelineVariableConfig(fake, "production", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_pipeline_variable.test", "settable", "false"),
+					resource.TestCheckResourceAttr("buddy_pipeline_variable.test", "value", "production"),
+				),
+			},
+			{
+				ResourceName:            "buddy_pipeline_variable.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"type"},
+			},
+		},
+	})
+}
+
+func TestAccResourcePipelineVariable_missingPipeline(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_pipeline_variable" "test" {
+  project     = "my-project"
+  pipeline_id = 999
+  key         = "ENVIRONMENT"
+  value       = "staging"
+}
+`,
+				ExpectError: regexp.MustCompile("Pipeline not found"),
+			},
+		},
+	})
+}
+
+func testAccResourcePipelineVariableConfig(fake *fakeBuddy, value string, settable bool) string {
+	return fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_pipeline" "test" {
+  project_name = "my-project"
+  name         = "Build"
+  on           = "CLICK"
+}
+
+resource "buddy_pipeline_variable" "test" {
+  project     = buddy_pipeline.test.project_name
+  pipeline_id = buddy_pipeline.test.pipeline_id
+  key         = "ENVIRONMENT"
+  value       = %q
+  settable    = %t
+}
+`, value, settable)
+}
+
+func TestAccResourcePipelineVariable_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVariableDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePipelineVariableConfig(fake, "staging", false),
+				Check: testAccCheckResourceDisappears("buddy_pipeline_variable.test", func(id string) error {
+					variableId, err := strconv.Atoi(id)
+					if err != nil {
+						return err
+					}
+					fake.removeVariable(variableId)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceProject() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_project` manages project on a Buddy workspace.\n\n" +
+			"Project can be created with Buddy hosted repository or attached to an external repository " +
+			"through one of the workspace integrations.",
+
+		CreateContext: resourceProjectCreate,
+		ReadContext:   resourceProjectRead,
+		UpdateContext: resourceProjectUpdate,
+		DeleteContext: resourceProjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Project name used in the URL. Generated from the display name when not set",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project display name",
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ACTIVE",
+				ValidateFunc: validation.StringInSlice([]string{"ACTIVE", "CLOSED"}, false),
+				Description:  "Project status. Valid values are `ACTIVE` and `CLOSED`",
+			},
+			"integration_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Hash ID of the integration used to attach an external repository",
+			},
+			"external_project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Repository name in the integrated service, e.g. `owner/repository` for GitHub",
+			},
+			"git_lab_project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Project ID in GitLab. Required when using a GitLab integration",
+			},
+			"custom_repo_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "URL of a custom Git repository",
+			},
+			"custom_repo_user": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Username used to access the custom Git repository",
+			},
+			"custom_repo_pass": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "Password used to access the custom Git repository",
+			},
+			"custom_repo_ssh_key_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "ID of the SSH key variable used to access the custom Git repository",
+			},
+			"html_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Project URL in the Buddy web interface",
+			},
+			"create_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Project creation date",
+			},
+			"http_repository": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "HTTP URL of the project repository",
+			},
+			"ssh_repository": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SSH URL of the project repository",
+			},
+			"default_branch": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Default branch of the project repository",
+			},
+		},
+	}
+}
+
+func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	project := buddyRequestCreateProject{
+		Name:               d.Get("name").(string),
+		DisplayName:        d.Get("display_name").(string),
+		ExternalProjectId:  d.Get("external_project_id").(string),
+		GitLabProjectId:    d.Get("git_lab_project_id").(string),
+		CustomRepoUrl:      d.Get("custom_repo_url").(string),
+		CustomRepoUser:     d.Get("custom_repo_user").(string),
+		CustomRepoPass:     d.Get("custom_repo_pass").(string),
+		CustomRepoSshKeyId: d.Get("custom_repo_ssh_key_id").(int),
+	}
+
+	if integrationId := d.Get("integration_id").(string); integrationId != "" {
+		project.Integration = &buddyRequestIntegration{
+			HashId: integrationId,
+		}
+	}
+
+	p, err := client.CreateProject(ctx, project)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	d.SetId(p.Name)
+
+	// Buddy always creates an active project, so closing it takes a second request
+	if status := d.Get("status").(string); status != p.Status {
+		p, err = client.UpdateProject(ctx, p.Name, buddyRequestUpdateProject{
+			Status: status,
+		})
+		if err != nil {
+			return buddyDiagnostics(err)
+		}
+	}
+
+	return setProject(d, p)
+}
+
+func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	name := d.Id()
+
+	project, err := client.ReadProject(ctx, name)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Project %v not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setProject(d, project)
+}
+
+func setProject(d *schema.ResourceData, project *buddyResponseProject) diag.Diagnostics {
+	if err := d.Set("name", project.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("display_name", project.DisplayName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("status", project.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("html_url", project.HTMLURL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("create_date", project.CreateDate); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("http_repository", project.HttpRepository); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ssh_repository", project.SshRepository); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("default_branch", project.DefaultBranch); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	project := buddyRequestUpdateProject{
+		DisplayName: d.Get("display_name").(string),
+		Status:      d.Get("status").(string),
+	}
+
+	if d.HasChange("name") {
+		project.Name = d.Get("name").(string)
+	}
+
+	p, err := client.UpdateProject(ctx, d.Id(), project)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	d.SetId(p.Name)
+	return setProject(d, p)
+}
+
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	err := client.DeleteProject(ctx, d.Id())
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return nil
+}
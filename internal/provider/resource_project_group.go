@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceProjectGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_project_group` manages group on a Buddy project.\n\n" +
+			"All members of the group are granted access to a project using the permission set ID.",
+
+		CreateContext: resourceProjectGroupCreate,
+		ReadContext:   resourceProjectGroupRead,
+		UpdateContext: resourceProjectGroupUpdate,
+		DeleteContext: resourceProjectGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project name",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Group ID",
+			},
+			"permission_set_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of permission set that will be granted to the group",
+			},
+		},
+	}
+}
+
+func resourceProjectGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	projectName := d.Get("project_name").(string)
+	groupId := d.Get("group_id").(string)
+	permissionSetId := d.Get("permission_set_id").(int)
+	request := buddyRequestProjectGroup{
+		Id: groupId,
+		PermissionSet: buddyId{
+			Id: permissionSetId,
+		},
+	}
+
+	group, err := client.CreateProjectGroup(ctx, projectName, request)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	id := fmt.Sprintf("%v:%v", projectName, groupId)
+
+	d.SetId(id)
+	return setProjectGroup(d, projectName, group)
+}
+
+func resourceProjectGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	ids := strings.Split(d.Id(), ":")
+	if len(ids) != 2 {
+		return diag.Errorf("Unexpected format of ID (%v), expected project_name:group_id", d.Id())
+	}
+
+	group, err := client.ReadProjectGroup(ctx, ids[0], ids[1])
+	if IsNotFound(err) {
+		log.Printf("[WARN] Project group %v not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setProjectGroup(d, ids[0], group)
+}
+
+func setProjectGroup(d *schema.ResourceData, projectName string, group *buddyResponseProjectGroup) diag.Diagnostics {
+	if err := d.Set("project_name", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("group_id", strconv.Itoa(group.Id)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("permission_set_id", group.PermissionSet.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceProjectGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	ids := strings.Split(d.Id(), ":")
+	permissionSetId := d.Get("permission_set_id").(int)
+	request := buddyRequestPermissionSet{
+		PermissionSet: buddyId{
+			Id: permissionSetId,
+		},
+	}
+
+	group, err := client.UpdateProjectGroup(ctx, ids[0], ids[1], request)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setProjectGroup(d, ids[0], group)
+}
+
+func resourceProjectGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	ids := strings.Split(d.Id(), ":")
+
+	err := client.DeleteProjectGroup(ctx, ids[0], ids[1])
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return nil
+}
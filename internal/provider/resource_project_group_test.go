@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceProjectGroup(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	developer := fake.permissionSetByName("Developer")
+	readOnly := fake.permissionSetByName("Read Only")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckProjectGroupDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceProjectGroupConfig(fake, developer.Id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project_group.test", "project_name", "my-project"),
+					resource.TestCheckResourceAttrPair("buddy_project_group.test", "group_id", "buddy_group.test", "id"),
+					resource.TestCheckResourceAttr("buddy_project_group.test", "permission_set_id", strconv.Itoa(developer.Id)),
+				),
+			},
+			{
+				Config: testAccResourceProjectGroupConfig(fake, readOnly.Id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project_group.test", "permission_set_id", strconv.Itoa(readOnly.Id)),
+				),
+			},
+			{
+				ResourceName:      "buddy_project_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceProjectGroup_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	developer := fake.permissionSetByName("Developer")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckProjectGroupDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceProjectGroupConfig(fake, developer.Id),
+				Check: testAccCheckResourceDisappears("buddy_project_group.test", func(id string) error {
+					ids := strings.Split(id, ":")
+					groupId, err := strconv.Atoi(ids[1])
+					if err != nil {
+						return err
+					}
+					fake.removeProjectGroup(ids[0], groupId)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceProjectGroupConfig(fake *fakeBuddy, permissionSetId int) string {
+	return fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_group" "test" {
+  name = "Developers"
+}
+
+resource "buddy_project_group" "test" {
+  project_name      = "my-project"
+  group_id          = buddy_group.test.id
+  permission_set_id = %v
+}
+`, permissionSetId)
+}
+
+func testAccCheckProjectGroupDestroy(fake *fakeBuddy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "buddy_project_group" {
+				continue
+			}
+
+			ids := strings.Split(rs.Primary.ID, ":")
+			groupId, err := strconv.Atoi(ids[1])
+			if err != nil {
+				return err
+			}
+
+			if fake.hasProjectGroup(ids[0], groupId) {
+				return fmt.Errorf("project group %v still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
 
@@ -13,7 +14,7 @@ import (
 func resourceProjectMember() *schema.Resource {
 	return &schema.Resource{
 		Description: "`buddy_project_member` manages member on a Buddy project.\n\n" +
-			"Member is granted access to a project using their ID and permission sets ID.",
+			"Member is granted access to a project using their ID or email and permission sets ID.",
 
 		CreateContext: resourceProjectMemberCreate,
 		ReadContext:   resourceProjectMemberRead,
@@ -22,6 +23,7 @@ func resourceProjectMember() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"project_name": {
 				Type:        schema.TypeString,
@@ -30,10 +32,21 @@ func resourceProjectMember() *schema.Resource {
 				Description: "Project name",
 			},
 			"member_id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Member ID",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				AtLeastOneOf: []string{"member_id", "email"},
+				Description:  "Member ID. Either `member_id` or `email` must be set",
+			},
+			"email": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"member_id"},
+				DiffSuppressFunc: suppressEmailCaseDiff,
+				Description:      "Email address of the member, resolved to the member ID. The email is matched ignoring case",
 			},
 			"permission_set_id": {
 				Type:        schema.TypeInt,
@@ -44,11 +57,37 @@ func resourceProjectMember() *schema.Resource {
 	}
 }
 
+// resolveMemberId returns the ID of the workspace member with the given email
+func resolveMemberId(ctx context.Context, client buddyClient, email string) (string, error) {
+	member, err := client.GetUser(ctx, email)
+	if err != nil {
+		return "", err
+	}
+
+	if member.Id == 0 {
+		return "", fmt.Errorf("User not found: " + email)
+	}
+
+	return strconv.Itoa(member.Id), nil
+}
+
+func suppressEmailCaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
 func resourceProjectMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(buddyClient)
 
 	projectName := d.Get("project_name").(string)
 	memberId := d.Get("member_id").(string)
+	if memberId == "" {
+		// The email is resolved on create, so plans don't look up every member
+		var err error
+		memberId, err = resolveMemberId(ctx, client, d.Get("email").(string))
+		if err != nil {
+			return buddyDiagnostics(err)
+		}
+	}
 	permissionSetId := d.Get("permission_set_id").(int)
 	variable := buddyRequestProjectMember{
 		Id: memberId,
@@ -57,27 +96,37 @@ func resourceProjectMemberCreate(ctx context.Context, d *schema.ResourceData, m
 		},
 	}
 
-	_, err := client.CreateProjectMember(projectName, variable)
+	member, err := client.CreateProjectMember(ctx, projectName, variable)
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
 	id := fmt.Sprintf("%v:%v", projectName, memberId)
 
 	d.SetId(id)
-	return resourceProjectMemberRead(ctx, d, m)
+	return setProjectMember(d, projectName, member)
 }
 
 func resourceProjectMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(buddyClient)
 	ids := strings.Split(d.Id(), ":")
 
-	member, err := client.ReadProjectMember(ids[0], ids[1])
+	member, err := client.ReadProjectMember(ctx, ids[0], ids[1])
+	if IsNotFound(err) {
+		log.Printf("[WARN] Project member %v not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
-	if err := d.Set("project_name", ids[0]); err != nil {
+	return setProjectMember(d, ids[0], member)
+}
+
+func setProjectMember(d *schema.ResourceData, projectName string, member *buddyResponseProjectMember) diag.Diagnostics {
+	if err := d.Set("project_name", projectName); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -85,6 +134,10 @@ func resourceProjectMemberRead(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("email", member.Email); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("permission_set_id", member.PermissionSet.Id); err != nil {
 		return diag.FromErr(err)
 	}
@@ -102,21 +155,21 @@ func resourceProjectMemberUpdate(ctx context.Context, d *schema.ResourceData, m
 		},
 	}
 
-	_, err := client.UpdateProjectMember(ids[0], ids[1], variable)
+	member, err := client.UpdateProjectMember(ctx, ids[0], ids[1], variable)
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
-	return resourceProjectMemberRead(ctx, d, m)
+	return setProjectMember(d, ids[0], member)
 }
 
 func resourceProjectMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(buddyClient)
 	ids := strings.Split(d.Id(), ":")
 
-	err := client.DeleteProjectMember(ids[0], ids[1])
+	err := client.DeleteProjectMember(ctx, ids[0], ids[1])
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
 	return nil
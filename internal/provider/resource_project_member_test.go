@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceProjectMember(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	member := fake.addMember("jane@example.com", "Jane Doe")
+	developer := fake.permissionSetByName("Developer")
+	readOnly := fake.permissionSetByName("Read Only")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckProjectMemberDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceProjectMemberConfig(fake, member.Id, developer.Id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project_member.test", "id", fmt.Sprintf("my-project:%v", member.Id)),
+					resource.TestCheckResourceAttr("buddy_project_member.test", "project_name", "my-project"),
+					resource.TestCheckResourceAttr("buddy_project_member.test", "member_id", strconv.Itoa(member.Id)),
+					resource.TestCheckResourceAttr("buddy_project_member.test", "permission_set_id", strconv.Itoa(developer.Id)),
+				),
+			},
+			{
+				Config: testAccResourceProjectMemberConfig(fake, member.Id, readOnly.Id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project_member.test", "permission_set_id", strconv.Itoa(readOnly.Id)),
+				),
+			},
+			{
+				ResourceName:      "buddy_project_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceProjectMemberConfig(fake *fakeBuddy, memberId int, permissionSetId int) string {
+	return fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_project_member" "test" {
+  project_name      = "my-project"
+  member_id         = "%v"
+  permission_set_id = %v
+}
+`, memberId, permissionSetId)
+}
+
+func testAccCheckProjectMemberDestroy(fake *fakeBuddy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "buddy_project_member" {
+				continue
+			}
+
+			ids := strings.Split(rs.Primary.ID, ":")
+			memberId, err := strconv.Atoi(ids[1])
+			if err != nil {
+				return err
+			}
+
+			if fake.hasProjectMember(ids[0], memberId) {
+				return fmt.Errorf("project member %v still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccResourceProjectMember_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	member := fake.addMember("jane@example.com", "Jane Doe")
+	developer := fake.permissionSetByName("Developer")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckProjectMemberDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceProjectMemberConfig(fake, member.Id, developer.Id),
+				Check: testAccCheckResourceDisappears("buddy_project_member.test", func(id string) error {
+					fake.removeProjectMember("my-project", member.Id)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceProjectMember_email(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	member := fake.addMember("jane@example.com", "Jane Doe")
+	developer := fake.permissionSetByName("Developer")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckProjectMemberDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_project_member" "test" {
+  project_name      = "my-project"
+  email             = "Jane@Example.com"
+  permission_set_id = %v
+}
+`, developer.Id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project_member.test", "id", fmt.Sprintf("my-project:%v", member.Id)),
+					resource.TestCheckResourceAttr("buddy_project_member.test", "member_id", strconv.Itoa(member.Id)),
+					resource.TestCheckResourceAttr("buddy_project_member.test", "email", "jane@example.com"),
+				),
+			},
+			{
+				ResourceName:      "buddy_project_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceProjectMember_emailNotFound(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_project_member" "test" {
+  project_name      = "my-project"
+  email             = "nobody@example.com"
+  permission_set_id = 1
+}
+`,
+				ExpectError: regexp.MustCompile("User not found: nobody@example.com"),
+			},
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_project_member" "test" {
+  project_name      = "my-project"
+  member_id         = "1"
+  email             = "nobody@example.com"
+  permission_set_id = 1
+}
+`,
+				ExpectError: regexp.MustCompile(`"email": conflicts with member_id`),
+			},
+		},
+	})
+}
+
+func TestResourceProjectMember_emailResolvedOnCreate(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	jane := fake.addMember("jane@example.com", "Jane Doe")
+	john := fake.addMember("john@example.com", "John Doe")
+	developer := fake.permissionSetByName("Developer")
+
+	r := resourceProjectMember()
+	config := map[string]interface{}{"project_name": "my-project", "email": "jane@example.com", "permission_set_id": developer.Id}
+	gets := fake.requestCount(http.MethodGet)
+
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), fake.client()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := fake.requestCount(http.MethodGet) - gets; n != 0 {
+		t.Fatalf("expected no GET requests when planning, got %v", n)
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, fake.client()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Get("member_id") != strconv.Itoa(jane.Id) {
+		t.Fatalf("expected member_id %v, got %v", jane.Id, d.Get("member_id"))
+	}
+
+	config["email"] = "john@example.com"
+	gets = fake.requestCount(http.MethodGet)
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), fake.client())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := fake.requestCount(http.MethodGet) - gets; n != 0 {
+		t.Fatalf("expected no GET requests when planning, got %v", n)
+	}
+	if !diff.RequiresNew() || !diff.Attributes["member_id"].NewComputed {
+		t.Fatalf("expected the member to be replaced with an unknown member_id, got %v", diff)
+	}
+
+	state, diags := r.Apply(context.Background(), d.State(), diff, fake.client())
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if state.Attributes["member_id"] != strconv.Itoa(john.Id) {
+		t.Fatalf("expected member_id %v, got %v", john.Id, state.Attributes["member_id"])
+	}
+}
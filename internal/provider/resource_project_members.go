@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceProjectMembers() *schema.Resource {
+	return &schema.Resource{
+		Description: "`buddy_project_members` manages the complete list of members of a Buddy project.\n\n" +
+			"The resource is authoritative: members of the project that aren't listed are removed from it. " +
+			"The members are read from Buddy on refresh, so members added outside of Terraform show up in the plan as removals. " +
+			"Import the resource before the first apply to review the members that would be removed. " +
+			"The workspace owner and the user the token belongs to are never removed, and are left out of `member` unless listed.\n\n" +
+			"Buddy doesn't list the permission sets of the members, so the permission set of a member is only read when the member isn't in the state yet. " +
+			"Permission sets changed outside of Terraform are not detected.\n\n" +
+			"Do not use it together with `buddy_project_member` on the same project.",
+
+		CreateContext: resourceProjectMembersCreate,
+		ReadContext:   resourceProjectMembersRead,
+		UpdateContext: resourceProjectMembersUpdate,
+		DeleteContext: resourceProjectMembersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: projectMembersCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Project name",
+			},
+			"member": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Members of the project",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"member_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Member ID",
+						},
+						"permission_set_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "ID of permission set that will be granted to the member",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func projectMembersCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("member") {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, raw := range d.Get("member").(*schema.Set).List() {
+		memberId := raw.(map[string]interface{})["member_id"].(string)
+		if memberId == "" {
+			continue
+		}
+
+		if seen[memberId] {
+			return fmt.Errorf("member %v is listed more than once", memberId)
+		}
+		seen[memberId] = true
+	}
+
+	return nil
+}
+
+// expandProjectMembers returns the permission set ID of the members by member ID
+func expandProjectMembers(members *schema.Set) map[string]int {
+	result := map[string]int{}
+	for _, raw := range members.List() {
+		member := raw.(map[string]interface{})
+		result[member["member_id"].(string)] = member["permission_set_id"].(int)
+	}
+
+	return result
+}
+
+// isProtectedProjectMember tells whether the member is never removed from the project for
+// not being listed. Removing the workspace owner or the user the token belongs to could
+// lock the provider out of the project.
+func isProtectedProjectMember(member buddyWorkspaceMember, currentUser *buddyWorkspaceMember) bool {
+	return member.WorkspaceOwner || member.Id == currentUser.Id
+}
+
+// readProjectMembers returns the permission set ID of the live project members by member ID.
+// Buddy doesn't include the permission set in the list, so it's taken from known and only the
+// other members are read. Unknown protected members are left out, as they are never removed.
+func readProjectMembers(ctx context.Context, client buddyClient, projectName string, known map[string]int) (map[string]int, error) {
+	list, err := client.ListProjectMembers(ctx, projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	var currentUser *buddyWorkspaceMember
+	members := map[string]int{}
+	for _, item := range list {
+		memberId := strconv.Itoa(item.Id)
+		if permissionSetId, ok := known[memberId]; ok {
+			members[memberId] = permissionSetId
+			continue
+		}
+
+		if currentUser == nil {
+			currentUser, err = client.GetCurrentUser(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if isProtectedProjectMember(item, currentUser) {
+			continue
+		}
+
+		member, err := client.ReadProjectMember(ctx, projectName, memberId)
+		if IsNotFound(err) {
+			// Removed while the list was read
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		members[memberId] = member.PermissionSet.Id
+	}
+
+	return members, nil
+}
+
+// applyProjectMembers makes the project members match the configuration. Members are
+// added and updated before the unlisted ones are removed, so the project is never
+// left without the configured members. The protected members are kept when unlisted.
+func applyProjectMembers(ctx context.Context, d *schema.ResourceData, client buddyClient, projectName string) diag.Diagnostics {
+	oldMembers, newMembers := d.GetChange("member")
+	known := expandProjectMembers(oldMembers.(*schema.Set))
+	desired := expandProjectMembers(newMembers.(*schema.Set))
+
+	list, err := client.ListProjectMembers(ctx, projectName)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	currentUser, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	var unlisted []string
+	current := map[string]bool{}
+	for _, item := range list {
+		memberId := strconv.Itoa(item.Id)
+		current[memberId] = true
+
+		permissionSetId, ok := desired[memberId]
+		if !ok {
+			if isProtectedProjectMember(item, currentUser) {
+				log.Printf("[INFO] Keeping unlisted project member %v:%v, it's the workspace owner or the user of the token", projectName, memberId)
+				continue
+			}
+
+			unlisted = append(unlisted, memberId)
+			continue
+		}
+
+		if knownId, ok := known[memberId]; ok && knownId == permissionSetId {
+			continue
+		}
+
+		log.Printf("[DEBUG] Changing permission set of project member %v:%v to %v", projectName, memberId, permissionSetId)
+		_, err := client.UpdateProjectMember(ctx, projectName, memberId, buddyRequestPermissionSet{
+			PermissionSet: buddyId{
+				Id: permissionSetId,
+			},
+		})
+		if err != nil {
+			return buddyDiagnostics(err)
+		}
+	}
+
+	for memberId, permissionSetId := range desired {
+		if current[memberId] {
+			continue
+		}
+
+		log.Printf("[DEBUG] Adding project member %v:%v", projectName, memberId)
+		_, err := client.CreateProjectMember(ctx, projectName, buddyRequestProjectMember{
+			Id: memberId,
+			PermissionSet: buddyId{
+				Id: permissionSetId,
+			},
+		})
+		if err != nil {
+			return buddyDiagnostics(err)
+		}
+	}
+
+	for _, memberId := range unlisted {
+		log.Printf("[DEBUG] Removing unlisted project member %v:%v", projectName, memberId)
+		err := client.DeleteProjectMember(ctx, projectName, memberId)
+		if err != nil && !IsNotFound(err) {
+			return buddyDiagnostics(err)
+		}
+	}
+
+	return setProjectMembers(d, projectName, desired)
+}
+
+func resourceProjectMembersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+	projectName := d.Get("project_name").(string)
+
+	// The ID is set first, so the members changed before a failure are refreshed instead of lost
+	d.SetId(projectName)
+
+	return applyProjectMembers(ctx, d, client, projectName)
+}
+
+func resourceProjectMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	members, err := readProjectMembers(ctx, client, d.Id(), expandProjectMembers(d.Get("member").(*schema.Set)))
+	if IsNotFound(err) {
+		log.Printf("[WARN] Project %v not found, removing members from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return buddyDiagnostics(err)
+	}
+
+	return setProjectMembers(d, d.Id(), members)
+}
+
+func setProjectMembers(d *schema.ResourceData, projectName string, members map[string]int) diag.Diagnostics {
+	if err := d.Set("project_name", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	list := make([]interface{}, 0, len(members))
+	for memberId, permissionSetId := range members {
+		list = append(list, map[string]interface{}{
+			"member_id":         memberId,
+			"permission_set_id": permissionSetId,
+		})
+	}
+
+	if err := d.Set("member", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceProjectMembersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	return applyProjectMembers(ctx, d, client, d.Id())
+}
+
+func resourceProjectMembersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(buddyClient)
+
+	for memberId := range expandProjectMembers(d.Get("member").(*schema.Set)) {
+		err := client.DeleteProjectMember(ctx, d.Id(), memberId)
+		if err != nil && !IsNotFound(err) {
+			return buddyDiagnostics(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceProjectMembers(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	jane := fake.addMember("jane@example.com", "Jane Doe")
+	john := fake.addMember("john@example.com", "John Doe")
+	outsider := fake.addMember("outsider@example.com", "Outsider")
+	developer := fake.permissionSetByName("Developer")
+	readOnly := fake.permissionSetByName("Read Only")
+	fake.addProjectMember("my-project", outsider.Id, developer.Id)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckProjectMembersDestroy(fake, jane.Id, john.Id),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceProjectMembersConfig(fake, map[int]int{jane.Id: developer.Id}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project_members.test", "id", "my-project"),
+					resource.TestCheckResourceAttr("buddy_project_members.test", "member.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("buddy_project_members.test", "member.*", map[string]string{
+						"member_id":         strconv.Itoa(jane.Id),
+						"permission_set_id": strconv.Itoa(developer.Id),
+					}),
+					testAccCheckProjectMemberExists(fake, jane.Id, true),
+					testAccCheckProjectMemberExists(fake, outsider.Id, false),
+				),
+			},
+			{
+				Config: testAccResourceProjectMembersConfig(fake, map[int]int{jane.Id: readOnly.Id, john.Id: developer.Id}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project_members.test", "member.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("buddy_project_members.test", "member.*", map[string]string{
+						"member_id":         strconv.Itoa(jane.Id),
+						"permission_set_id": strconv.Itoa(readOnly.Id),
+					}),
+					testAccCheckProjectMemberExists(fake, john.Id, true),
+				),
+			},
+			{
+				ResourceName:      "buddy_project_members.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceProjectMembers_removesUnmanaged(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	jane := fake.addMember("jane@example.com", "Jane Doe")
+	outsider := fake.addMember("outsider@example.com", "Outsider")
+	developer := fake.permissionSetByName("Developer")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckProjectMembersDestroy(fake, jane.Id),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceProjectMembersConfig(fake, map[int]int{jane.Id: developer.Id}),
+				Check: func(s *terraform.State) error {
+					fake.addProjectMember("my-project", outsider.Id, developer.Id)
+					return nil
+				},
+				// the member added outside of Terraform is planned for removal
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccResourceProjectMembersConfig(fake, map[int]int{jane.Id: developer.Id}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project_members.test", "member.#", "1"),
+					testAccCheckProjectMemberExists(fake, outsider.Id, false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceProjectMembers_duplicateMember(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_project_members" "test" {
+  project_name = "my-project"
+
+  member {
+    member_id         = "1"
+    permission_set_id = 1
+  }
+
+  member {
+    member_id         = "1"
+    permission_set_id = 2
+  }
+}
+`,
+				ExpectError: regexp.MustCompile("member 1 is listed more than once"),
+			},
+		},
+	})
+}
+
+func TestResourceProjectMembersCreate_keepsProtectedMembers(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	owner := fake.addMember("owner@example.com", "Owner")
+	owner.WorkspaceOwner = true
+	self := fake.addMember("self@example.com", "Token Owner")
+	fake.currentUser = self.Id
+	jane := fake.addMember("jane@example.com", "Jane Doe")
+	outsider := fake.addMember("outsider@example.com", "Outsider")
+	developer := fake.permissionSetByName("Developer")
+	for _, memberId := range []int{owner.Id, self.Id, outsider.Id} {
+		fake.addProjectMember("my-project", memberId, developer.Id)
+	}
+
+	r := resourceProjectMembers()
+	d := schema.TestResourceDataRaw(t, r.Schema, testProjectMembersConfig(map[int]int{jane.Id: developer.Id}))
+	if diags := r.CreateContext(context.Background(), d, fake.client()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for memberId, exists := range map[int]bool{owner.Id: true, self.Id: true, jane.Id: true, outsider.Id: false} {
+		if fake.hasProjectMember("my-project", memberId) != exists {
+			t.Fatalf("expected project member %v to exist: %v", memberId, exists)
+		}
+	}
+
+	if diags := r.ReadContext(context.Background(), d, fake.client()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if n := d.Get("member.#"); n != 1 {
+		t.Fatalf("expected the unlisted protected members to be left out of the state, got %v members", n)
+	}
+}
+
+func TestResourceProjectMembersRead_listsOnce(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	jane := fake.addMember("jane@example.com", "Jane Doe")
+	john := fake.addMember("john@example.com", "John Doe")
+	developer := fake.permissionSetByName("Developer")
+
+	r := resourceProjectMembers()
+	d := schema.TestResourceDataRaw(t, r.Schema, testProjectMembersConfig(map[int]int{jane.Id: developer.Id, john.Id: developer.Id}))
+	if diags := r.CreateContext(context.Background(), d, fake.client()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	gets := fake.requestCount(http.MethodGet)
+	if diags := r.ReadContext(context.Background(), d, fake.client()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if n := fake.requestCount(http.MethodGet) - gets; n != 1 {
+		t.Fatalf("expected the refresh to only list the members, got %v GET requests", n)
+	}
+	if n := d.Get("member.#"); n != 2 {
+		t.Fatalf("expected 2 members, got %v", n)
+	}
+}
+
+func TestResourceProjectMembersCreate_partialFailureKeepsId(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+	jane := fake.addMember("jane@example.com", "Jane Doe")
+	developer := fake.permissionSetByName("Developer")
+
+	r := resourceProjectMembers()
+	d := schema.TestResourceDataRaw(t, r.Schema, testProjectMembersConfig(map[int]int{jane.Id: developer.Id, 999: developer.Id}))
+	if diags := r.CreateContext(context.Background(), d, fake.client()); !diags.HasError() {
+		t.Fatalf("expected an error for the unknown member")
+	}
+	if d.Id() != "my-project" {
+		t.Fatalf("expected the ID to be set before the members are changed, got %q", d.Id())
+	}
+}
+
+func testProjectMembersConfig(members map[int]int) map[string]interface{} {
+	list := make([]interface{}, 0, len(members))
+	for memberId, permissionSetId := range members {
+		list = append(list, map[string]interface{}{
+			"member_id":         strconv.Itoa(memberId),
+			"permission_set_id": permissionSetId,
+		})
+	}
+
+	return map[string]interface{}{
+		"project_name": "my-project",
+		"member":       list,
+	}
+}
+
+func testAccResourceProjectMembersConfig(fake *fakeBuddy, members map[int]int) string {
+	config := fake.providerConfig() + `
+resource "buddy_project_members" "test" {
+  project_name = "my-project"
+`
+	for memberId, permissionSetId := range members {
+		config += fmt.Sprintf(`
+  member {
+    member_id         = "%v"
+    permission_set_id = %v
+  }
+`, memberId, permissionSetId)
+	}
+
+	return config + "}\n"
+}
+
+func testAccCheckProjectMemberExists(fake *fakeBuddy, memberId int, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if fake.hasProjectMember("my-project", memberId) != exists {
+			return fmt.Errorf("expected project member %v to exist: %v", memberId, exists)
+		}
+		return nil
+	}
+}
+
+func testAccCheckProjectMembersDestroy(fake *fakeBuddy, memberIds ...int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, memberId := range memberIds {
+			if fake.hasProjectMember("my-project", memberId) {
+				return fmt.Errorf("project member %v still exists", memberId)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceProject(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckProjectDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceProjectConfig(fake, "My Project", "ACTIVE"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project.test", "id", "my-project"),
+					resource.TestCheckResourceAttr("buddy_project.test", "name", "my-project"),
+					resource.TestCheckResourceAttr("buddy_project.test", "display_name", "My Project"),
+					resource.TestCheckResourceAttr("buddy_project.test", "status", "ACTIVE"),
+					resource.TestCheckResourceAttrSet("buddy_project.test", "html_url"),
+					resource.TestCheckResourceAttrSet("buddy_project.test", "create_date"),
+				),
+			},
+			{
+				Config: testAccResourceProjectConfig(fake, "My Renamed Project", "CLOSED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project.test", "name", "my-project"),
+					resource.TestCheckResourceAttr("buddy_project.test", "display_name", "My Renamed Project"),
+					resource.TestCheckResourceAttr("buddy_project.test", "status", "CLOSED"),
+				),
+			},
+			{
+				ResourceName:      "buddy_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceProject_referencedByProjectVariable(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckProjectDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_project" "test" {
+  name         = "backend"
+  display_name = "Backend"
+}
+
+resource "buddy_project_variable" "test" {
+  project = buddy_project.test.name
+  key     = "ENVIRONMENT"
+  value   = "staging"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project.test", "name", "backend"),
+					resource.TestCheckResourceAttr("buddy_project_variable.test", "project", "backend"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceProject_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckProjectDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceProjectConfig(fake, "My Project", "ACTIVE"),
+				Check: testAccCheckResourceDisappears("buddy_project.test", func(id string) error {
+					fake.removeProject(id)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceProjectConfig(fake *fakeBuddy, displayName string, status string) string {
+	return fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_project" "test" {
+  display_name = %q
+  status       = %q
+}
+`, displayName, status)
+}
+
+func testAccCheckProjectDestroy(fake *fakeBuddy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "buddy_project" {
+				continue
+			}
+
+			if fake.hasProject(rs.Primary.ID) {
+				return fmt.Errorf("project %v still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
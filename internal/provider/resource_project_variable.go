@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"log"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -21,59 +22,15 @@ func resourceProjectVariable() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: map[string]*schema.Schema{
-			"key": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Variable name",
-			},
-			"value": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Variable value",
-				Sensitive:   true,
-			},
-			"value_hash": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Hash of the encrypted variable value",
-			},
-			"type": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "VAR",
-				Description: "Variable type. Currently only support VAR",
-			},
-			"description": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Variable description",
-			},
-			"settable": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Flag to decide whether the variable is settable by pipeline run",
-			},
-			"encrypted": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Flag to decide whether variable encrypted",
-			},
-			"ssh_key": {
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "Flag to decide whether the variable is an SSH key",
-			},
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: variableCustomizeDiff,
+		Schema: variableSchema(map[string]*schema.Schema{
 			"project": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "Project name where variable will be created",
 			},
-		},
+		}),
 	}
 }
 
@@ -97,54 +54,40 @@ func resourceProjectVariableCreate(ctx context.Context, d *schema.ResourceData,
 		Project: buddyRequestProject{
 			Name: project,
 		},
+
+		buddyRequestVariableFile: expandVariableFile(d),
 	}
 
-	v, err := client.CreateProjectVariable(variable)
+	v, err := client.CreateProjectVariable(ctx, variable)
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
 	d.SetId(strconv.Itoa(v.Id))
-	return resourceWorkpaceVariableRead(ctx, d, m)
+	return setProjectVariable(d, v)
 }
 
 func resourceProjectVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(buddyClient)
 
 	id := d.Id()
-	data, err := client.ReadProjectVariable(id)
-	if err != nil {
-		return diag.FromErr(err)
+	data, err := client.ReadProjectVariable(ctx, id)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Project variable %v not found, removing from state", id)
+		d.SetId("")
+		return nil
 	}
 
-	if err := d.Set("key", data.Key); err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := d.Set("ssh_key", data.SSHKey); err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := d.Set("settable", data.Settable); err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := d.Set("encrypted", data.Encrypted); err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := d.Set("description", data.Description); err != nil {
-		return diag.FromErr(err)
+	if err != nil {
+		return buddyDiagnostics(err)
 	}
 
-	if err := d.Set("value_hash", data.Value); err != nil {
-		return diag.FromErr(err)
-	}
+	return setProjectVariable(d, data)
+}
 
-	if !data.Encrypted {
-		if err := d.Set("value", data.Value); err != nil {
-			return diag.FromErr(err)
-		}
+func setProjectVariable(d *schema.ResourceData, data *buddyResponseProjectVariable) diag.Diagnostics {
+	if diags := setVariable(d, data.buddyResponseVariable); diags.HasError() {
+		return diags
 	}
 
 	if err := d.Set("project", data.Project.Name); err != nil {
@@ -175,23 +118,25 @@ func resourceProjectVariableUpdate(ctx context.Context, d *schema.ResourceData,
 		Project: buddyRequestProject{
 			Name: project,
 		},
+
+		buddyRequestVariableFile: expandVariableFile(d),
 	}
 
-	_, err := client.UpdateProjectVariable(id, variable)
+	v, err := client.UpdateProjectVariable(ctx, id, variable)
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
-	return resourceProjectVariableRead(ctx, d, m)
+	return setProjectVariable(d, v)
 }
 
 func resourceProjectVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(buddyClient)
 
 	id := d.Id()
-	err := client.DeleteVariable(id)
+	err := client.DeleteVariable(ctx, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 	return nil
 }
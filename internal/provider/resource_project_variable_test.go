@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceProjectVariable(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVariableDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceProjectVariableConfig(fake, "staging", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project_variable.test", "key", "ENVIRONMENT"),
+					resource.TestCheckResourceAttr("buddy_project_variable.test", "project", "my-project"),
+					resource.TestCheckResourceAttr("buddy_project_variable.test", "settable", "true"),
+					resource.TestCheckResourceAttr("buddy_project_variable.test", "value", "staging"),
+				),
+			},
+			{
+				Config: testAccResourceProjectVariableConfig(fake, "production", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_project_variable.test", "settable", "false"),
+					resource.TestCheckResourceAttr("buddy_project_variable.test", "value", "production"),
+				),
+			},
+			{
+				ResourceName:            "buddy_project_variable.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"type"},
+			},
+		},
+	})
+}
+
+func TestAccResourceProjectVariable_missingProject(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceProjectVariableConfig(fake, "staging", false),
+				ExpectError: regexp.MustCompile("Project not found"),
+			},
+		},
+	})
+}
+
+func testAccResourceProjectVariableConfig(fake *fakeBuddy, value string, settable bool) string {
+	return fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_project_variable" "test" {
+  project  = "my-project"
+  key      = "ENVIRONMENT"
+  value    = %q
+  settable = %t
+}
+`, value, settable)
+}
+
+func TestAccResourceProjectVariable_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+	fake.addProject("my-project")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVariableDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceProjectVariableConfig(fake, "staging", false),
+				Check: testAccCheckResourceDisappears("buddy_project_variable.test", func(id string) error {
+					variableId, err := strconv.Atoi(id)
+					if err != nil {
+						return err
+					}
+					fake.removeVariable(variableId)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
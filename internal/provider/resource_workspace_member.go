@@ -2,16 +2,30 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+const invitationPending = "PENDING"
+
+// invitationPollInterval is how often the member is read while waiting for the
+// invitation to be accepted, lowered in tests
+var invitationPollInterval = 10 * time.Second
+
 func resourceWorkspaceMember() *schema.Resource {
 	return &schema.Resource{
 		Description: "`buddy_workspace_member` manages member on a Buddy workspace.\n\n" +
-			"A new member can be invited into a workspace using their email address.",
+			"A new member can be invited into a workspace using their email address. " +
+			"When the email already belongs to a member of the workspace, the member is managed instead of invited again. " +
+			"Such member keeps the admin right it has, which is only granted when `admin` is `true`; " +
+			"when it differs from `admin`, the next plan shows the change. " +
+			"Destroying the resource removes the member from the workspace like any other member.",
 
 		CreateContext: resourceWorkspaceMemberCreate,
 		ReadContext:   resourceWorkspaceMemberRead,
@@ -20,12 +34,14 @@ func resourceWorkspaceMember() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"email": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Email address to be invited into Buddy workspace",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressEmailCaseDiff,
+				Description:      "Email address to be invited into Buddy workspace. The email is matched ignoring case",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -38,6 +54,22 @@ func resourceWorkspaceMember() *schema.Resource {
 				Default:     false,
 				Description: "Flag to indicate whether member has admin right",
 			},
+			"wait_for_acceptance": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait until the member accepts the invitation. The wait is limited by the `create` and `update` timeouts, after which a warning is reported and the member is kept with the invitation pending",
+			},
+			"resend_invitation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value, changing it sends the invitation again when it's still pending",
+			},
+			"invitation_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the invitation, `PENDING` until the member accepts it and `ACCEPTED` afterwards",
+			},
 		},
 	}
 }
@@ -46,32 +78,120 @@ func resourceWorkspaceMemberCreate(ctx context.Context, d *schema.ResourceData,
 	client := m.(buddyClient)
 
 	email := d.Get("email").(string)
+
 	admin := d.Get("admin").(bool)
 
-	member, err := client.CreateWorkspaceMember(email)
+	member, err := client.CreateWorkspaceMember(ctx, email)
+	adopted := false
+	if IsConflict(err) || hasStatus(err, http.StatusBadRequest) {
+		// A duplicate email is rejected as a conflict or a validation error, so the
+		// workspace members are only looked up when the invitation fails
+		member, err = adoptWorkspaceMember(ctx, client, email, err)
+		adopted = true
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
 	id := strconv.Itoa(member.Id)
-	_, err = client.SetAdminRight(id, admin)
-	if err != nil {
-		return diag.FromErr(err)
+	// An adopted member isn't demoted, its admin right is only granted when requested
+	if !adopted || (admin && !member.Admin) {
+		member, err = client.SetAdminRight(ctx, id, admin)
+		if err != nil {
+			return buddyDiagnostics(err)
+		}
 	}
 
 	d.SetId(id)
-	return resourceWorkspaceMemberRead(ctx, d, m)
+
+	if d.Get("wait_for_acceptance").(bool) {
+		member, err = waitForInvitationAcceptance(ctx, client, member)
+		if err != nil {
+			return buddyDiagnostics(err)
+		}
+		if member.InvitationStatus == invitationPending {
+			return append(setWorkspaceMember(d, member), invitationPendingWarning(member))
+		}
+	}
+
+	return setWorkspaceMember(d, member)
+}
+
+// adoptWorkspaceMember returns the member the email belongs to, or inviteErr when
+// the invitation failed for another reason
+func adoptWorkspaceMember(ctx context.Context, client buddyClient, email string, inviteErr error) (*buddyResponseWorkspaceMember, error) {
+	existing, err := client.GetUser(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.Id == 0 {
+		return nil, inviteErr
+	}
+
+	log.Printf("[INFO] Email %v already belongs to workspace member %v, adopting it", email, existing.Id)
+	member := buddyResponseWorkspaceMember(*existing)
+	return &member, nil
+}
+
+// waitForInvitationAcceptance reads the member until the invitation is accepted or the
+// context, bound to the operation timeout, is done. On timeout the last read member is
+// returned, with the invitation still pending.
+func waitForInvitationAcceptance(ctx context.Context, client buddyClient, member *buddyResponseWorkspaceMember) (*buddyResponseWorkspaceMember, error) {
+	ticker := time.NewTicker(invitationPollInterval)
+	defer ticker.Stop()
+
+	for member.InvitationStatus == invitationPending {
+		log.Printf("[DEBUG] Waiting for workspace member %v to accept the invitation", member.Id)
+
+		select {
+		case <-ctx.Done():
+			return member, nil
+		case <-ticker.C:
+		}
+
+		current, err := client.ReadWorkspaceMember(ctx, strconv.Itoa(member.Id))
+		if err != nil && ctx.Err() != nil {
+			return member, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		member = current
+	}
+
+	return member, nil
+}
+
+// invitationPendingWarning reports a wait that timed out. It is a warning, so the member
+// is kept in the state instead of being tainted and invited again on the next apply.
+func invitationPendingWarning(member *buddyResponseWorkspaceMember) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Timeout while waiting for %v to accept the invitation", member.Email),
+		Detail:   "The member is kept in the state with the invitation_status PENDING.",
+	}
 }
 
 func resourceWorkspaceMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(buddyClient)
 	id := d.Id()
 
-	member, err := client.ReadWorkspaceMember(id)
+	member, err := client.ReadWorkspaceMember(ctx, id)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Workspace member %v not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
+	return setWorkspaceMember(d, member)
+}
+
+func setWorkspaceMember(d *schema.ResourceData, member *buddyResponseWorkspaceMember) diag.Diagnostics {
 	if err := d.Set("email", member.Email); err != nil {
 		return diag.FromErr(err)
 	}
@@ -84,29 +204,59 @@ func resourceWorkspaceMemberRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("invitation_status", member.InvitationStatus); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
 func resourceWorkspaceMemberUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(buddyClient)
 	id := d.Id()
-	admin := d.Get("admin").(bool)
 
-	_, err := client.SetAdminRight(id, admin)
+	var member *buddyResponseWorkspaceMember
+	var err error
+	if d.HasChange("admin") {
+		member, err = client.SetAdminRight(ctx, id, d.Get("admin").(bool))
+	} else {
+		member, err = client.ReadWorkspaceMember(ctx, id)
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
+	}
+
+	if d.HasChange("resend_invitation_trigger") {
+		if member.InvitationStatus == invitationPending {
+			err := client.ResendInvitation(ctx, id)
+			if err != nil {
+				return buddyDiagnostics(err)
+			}
+		} else {
+			log.Printf("[DEBUG] Workspace member %v already accepted the invitation, not resending it", id)
+		}
 	}
 
-	return resourceWorkspaceMemberRead(ctx, d, m)
+	if d.Get("wait_for_acceptance").(bool) {
+		member, err = waitForInvitationAcceptance(ctx, client, member)
+		if err != nil {
+			return buddyDiagnostics(err)
+		}
+		if member.InvitationStatus == invitationPending {
+			return append(setWorkspaceMember(d, member), invitationPendingWarning(member))
+		}
+	}
+
+	return setWorkspaceMember(d, member)
 }
 
 func resourceWorkspaceMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(buddyClient)
 	id := d.Id()
 
-	err := client.DeleteWorkspaceMember(id)
+	err := client.DeleteWorkspaceMember(ctx, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
 	return nil
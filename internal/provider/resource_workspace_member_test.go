@@ -0,0 +1,389 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceWorkspaceMember(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckWorkspaceMemberDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceWorkspaceMemberConfig(fake, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "email", "jane@example.com"),
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "name", "jane"),
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "admin", "false"),
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "invitation_status", "PENDING"),
+				),
+			},
+			{
+				Config: testAccResourceWorkspaceMemberConfig(fake, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "admin", "true"),
+				),
+			},
+			{
+				ResourceName:            "buddy_workspace_member.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_acceptance"},
+			},
+		},
+	})
+}
+
+func testAccResourceWorkspaceMemberConfig(fake *fakeBuddy, admin bool) string {
+	return fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_workspace_member" "test" {
+  email = "jane@example.com"
+  admin = %t
+}
+`, admin)
+}
+
+func testAccCheckWorkspaceMemberDestroy(fake *fakeBuddy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "buddy_workspace_member" {
+				continue
+			}
+
+			id, err := strconv.Atoi(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			if fake.hasMember(id) {
+				return fmt.Errorf("workspace member %v still exists", id)
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccResourceWorkspaceMember_disappears(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckWorkspaceMemberDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceWorkspaceMemberConfig(fake, false),
+				Check: testAccCheckResourceDisappears("buddy_workspace_member.test", func(id string) error {
+					memberId, err := strconv.Atoi(id)
+					if err != nil {
+						return err
+					}
+					fake.removeMember(memberId)
+					return nil
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceWorkspaceMember_adoptsExistingMember(t *testing.T) {
+	fake := newFakeBuddy(t)
+	member := fake.addMember("jane@example.com", "Jane Doe")
+	member.Admin = true
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckWorkspaceMemberDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_workspace_member" "test" {
+  email = "jane@example.com"
+  admin = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "id", strconv.Itoa(member.Id)),
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "name", "Jane Doe"),
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "admin", "true"),
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "invitation_status", "ACCEPTED"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceWorkspaceMember_resendInvitation(t *testing.T) {
+	fake := newFakeBuddy(t)
+	member := fake.inviteMember("jane@example.com")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckWorkspaceMemberDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceWorkspaceMemberResendConfig(fake, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "invitation_status", "PENDING"),
+					testAccCheckInvitationCount(fake, member.Id, 1),
+				),
+			},
+			{
+				Config: testAccResourceWorkspaceMemberResendConfig(fake, "second"),
+				Check:  testAccCheckInvitationCount(fake, member.Id, 2),
+			},
+			{
+				PreConfig: func() {
+					fake.acceptInvitation(member.Id)
+				},
+				Config: testAccResourceWorkspaceMemberResendConfig(fake, "third"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "invitation_status", "ACCEPTED"),
+					testAccCheckInvitationCount(fake, member.Id, 2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceWorkspaceMember_waitForAcceptance(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	interval := invitationPollInterval
+	invitationPollInterval = 10 * time.Millisecond
+	defer func() { invitationPollInterval = interval }()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckWorkspaceMemberDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "buddy_workspace_member" "test" {
+  email               = "jane@example.com"
+  wait_for_acceptance = true
+
+  timeouts {
+    create = "1s"
+  }
+}
+`,
+				// the timeout is a warning, so the member isn't tainted
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("buddy_workspace_member.test", "invitation_status", "PENDING"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceWorkspaceMemberResendConfig(fake *fakeBuddy, trigger string) string {
+	return fake.providerConfig() + fmt.Sprintf(`
+resource "buddy_workspace_member" "test" {
+  email                     = "jane@example.com"
+  resend_invitation_trigger = "%v"
+}
+`, trigger)
+}
+
+func testAccCheckInvitationCount(fake *fakeBuddy, id int, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := fake.invitationCount(id); n != expected {
+			return fmt.Errorf("expected %v invitations sent to member %v, got %v", expected, id, n)
+		}
+		return nil
+	}
+}
+
+func TestWaitForInvitationAcceptance(t *testing.T) {
+	fake := newFakeBuddy(t)
+	client := fake.client()
+	member := fake.inviteMember("jane@example.com")
+
+	interval := invitationPollInterval
+	invitationPollInterval = 10 * time.Millisecond
+	defer func() { invitationPollInterval = interval }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	pending, err := waitForInvitationAcceptance(ctx, client, member)
+	if err != nil {
+		t.Fatalf("expected the timeout to return the pending member, got %v", err)
+	}
+	if pending.InvitationStatus != "PENDING" {
+		t.Fatalf("unexpected member: %+v", pending)
+	}
+
+	if err := client.ResendInvitation(context.Background(), strconv.Itoa(member.Id)); err != nil {
+		t.Fatalf("ResendInvitation returned an error: %v", err)
+	}
+	if n := fake.invitationCount(member.Id); n != 2 {
+		t.Fatalf("expected the invitation to be sent again, got %v invitations", n)
+	}
+
+	time.AfterFunc(30*time.Millisecond, func() {
+		fake.acceptInvitation(member.Id)
+	})
+	accepted, err := waitForInvitationAcceptance(context.Background(), client, member)
+	if err != nil {
+		t.Fatalf("waitForInvitationAcceptance returned an error: %v", err)
+	}
+	if accepted.InvitationStatus != "ACCEPTED" {
+		t.Fatalf("unexpected member: %+v", accepted)
+	}
+
+	if err := client.ResendInvitation(context.Background(), strconv.Itoa(member.Id)); err == nil {
+		t.Fatalf("expected an error when resending an accepted invitation")
+	}
+}
+
+func TestResourceWorkspaceMemberCreate_waitTimeoutKeepsMember(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	interval := invitationPollInterval
+	invitationPollInterval = 10 * time.Millisecond
+	defer func() { invitationPollInterval = interval }()
+
+	r := resourceWorkspaceMember()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"email": "jane@example.com", "wait_for_acceptance": true})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	diags := r.CreateContext(ctx, d, fake.client())
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if d.Id() == "" || d.Get("invitation_status") != "PENDING" {
+		t.Fatalf("expected the pending member to be kept in the state, got ID %q and status %v", d.Id(), d.Get("invitation_status"))
+	}
+}
+
+func TestResourceWorkspaceMemberCreate_adoptKeepsAdmin(t *testing.T) {
+	fake := newFakeBuddy(t)
+	admin := fake.addMember("jane@example.com", "Jane Doe")
+	admin.Admin = true
+	member := fake.addMember("john@example.com", "John Doe")
+
+	cases := map[string]struct {
+		config   map[string]interface{}
+		id       int
+		expected bool
+	}{
+		"admin not demoted": {map[string]interface{}{"email": "jane@example.com"}, admin.Id, true},
+		"admin granted":     {map[string]interface{}{"email": "john@example.com", "admin": true}, member.Id, true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := resourceWorkspaceMember()
+			d := schema.TestResourceDataRaw(t, r.Schema, tc.config)
+
+			if diags := r.CreateContext(context.Background(), d, fake.client()); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if d.Id() != strconv.Itoa(tc.id) {
+				t.Fatalf("expected member %v to be adopted, got ID %q", tc.id, d.Id())
+			}
+			if d.Get("admin") != tc.expected {
+				t.Fatalf("expected admin to be %v, got %v", tc.expected, d.Get("admin"))
+			}
+		})
+	}
+}
+
+func TestResourceWorkspaceMemberUpdate_revokesAdmin(t *testing.T) {
+	fake := newFakeBuddy(t)
+
+	r := resourceWorkspaceMember()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"email": "jane@example.com", "admin": true})
+	if diags := r.CreateContext(context.Background(), d, fake.client()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"email": "jane@example.com"})
+	diff, err := r.Diff(context.Background(), d.State(), config, fake.client())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.Empty() {
+		t.Fatalf("expected removing admin from the config to show in the plan")
+	}
+
+	state, diags := r.Apply(context.Background(), d.State(), diff, fake.client())
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if state.Attributes["admin"] != "false" {
+		t.Fatalf("expected the admin right to be revoked, got %v", state.Attributes["admin"])
+	}
+}
+
+func TestResourceWorkspaceMemberCreate_adoptEmailCase(t *testing.T) {
+	fake := newFakeBuddy(t)
+	member := fake.addMember("jane@example.com", "Jane Doe")
+
+	r := resourceWorkspaceMember()
+	config := map[string]interface{}{"email": "Jane@example.com"}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+	if diags := r.CreateContext(context.Background(), d, fake.client()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != strconv.Itoa(member.Id) || d.Get("email") != "jane@example.com" {
+		t.Fatalf("expected member %v to be adopted, got ID %q and email %v", member.Id, d.Id(), d.Get("email"))
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), fake.client())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !diff.Empty() {
+		t.Fatalf("expected an empty plan after adopting the member, got %v", diff)
+	}
+}
+
+func TestAdoptWorkspaceMember_conflict(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/members":
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"errors":[{"message":"Member already exists"}]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/members":
+			fmt.Fprint(w, `{"members":[{"id":7,"email":"jane@example.com","admin":true,"invitation_status":"ACCEPTED"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newBuddyClient(&Config{BuddyURL: server.URL, Token: fakeBuddyToken})
+	r := resourceWorkspaceMember()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"email": "Jane@example.com"})
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "7" || d.Get("admin") != true {
+		t.Fatalf("expected member 7 to be adopted, got ID %q and admin %v", d.Id(), d.Get("admin"))
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"email": "john@example.com"})
+	diags := r.CreateContext(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "Member already exists") {
+		t.Fatalf("expected the invitation error when no member has the email, got %v", diags)
+	}
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"log"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -21,54 +22,9 @@ func resourceWorkspaceVariable() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: map[string]*schema.Schema{
-			"key": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Variable name",
-			},
-			"value": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Variable value",
-				Sensitive:   true,
-			},
-			"value_hash": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Hash of the encrypted variable value",
-			},
-			"type": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "VAR",
-				Description: "Variable type. Currently only support VAR",
-			},
-			"description": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Variable description",
-			},
-			"settable": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Flag to decide whether the variable is settable by pipeline run",
-			},
-			"encrypted": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Flag to decide whether variable encrypted",
-			},
-			"ssh_key": {
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "Flag to decide whether the variable is an SSH key",
-			},
-		},
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: variableCustomizeDiff,
+		Schema:        variableSchema(nil),
 	}
 }
 
@@ -88,57 +44,39 @@ func resourceWorkspaceVariableCreate(ctx context.Context, d *schema.ResourceData
 		Description: description,
 		Settable:    settable,
 		Encrypted:   encrypted,
+
+		buddyRequestVariableFile: expandVariableFile(d),
 	}
 
-	globalVar, err := client.CreateWorkspaceVariable(variable)
+	globalVar, err := client.CreateWorkspaceVariable(ctx, variable)
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
 	d.SetId(strconv.Itoa(globalVar.Id))
-	return resourceWorkpaceVariableRead(ctx, d, m)
+	return setWorkspaceVariable(d, globalVar)
 }
 
 func resourceWorkpaceVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(buddyClient)
 
 	id := d.Id()
-	data, err := client.ReadWorkspaceVariable(id)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := d.Set("key", data.Key); err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := d.Set("ssh_key", data.SSHKey); err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := d.Set("settable", data.Settable); err != nil {
-		return diag.FromErr(err)
+	data, err := client.ReadWorkspaceVariable(ctx, id)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Workspace variable %v not found, removing from state", id)
+		d.SetId("")
+		return nil
 	}
 
-	if err := d.Set("encrypted", data.Encrypted); err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := d.Set("description", data.Description); err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := d.Set("value_hash", data.Value); err != nil {
-		return diag.FromErr(err)
+	if err != nil {
+		return buddyDiagnostics(err)
 	}
 
-	if !data.Encrypted {
-		if err := d.Set("value", data.Value); err != nil {
-			return diag.FromErr(err)
-		}
-	}
+	return setWorkspaceVariable(d, data)
+}
 
-	return nil
+func setWorkspaceVariable(d *schema.ResourceData, data *buddyResponseWorkspaceVariable) diag.Diagnostics {
+	return setVariable(d, data.buddyResponseVariable)
 }
 
 func resourceWorkspaceVariableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -158,23 +96,25 @@ func resourceWorkspaceVariableUpdate(ctx context.Context, d *schema.ResourceData
 		Description: description,
 		Settable:    settable,
 		Encrypted:   encrypted,
+
+		buddyRequestVariableFile: expandVariableFile(d),
 	}
 
-	_, err := client.UpdateWorkspaceVariable(id, variable)
+	v, err := client.UpdateWorkspaceVariable(ctx, id, variable)
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 
-	return resourceWorkpaceVariableRead(ctx, d, m)
+	return setWorkspaceVariable(d, v)
 }
 
 func resourceWorkpaceVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(buddyClient)
 
 	id := d.Id()
-	err := client.DeleteVariable(id)
+	err := client.DeleteVariable(ctx, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return buddyDiagnostics(err)
 	}
 	return nil
 }
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newTestRetryTransport returns a transport that records the waits instead of sleeping
+func newTestRetryTransport(maxRetries int, waits *[]time.Duration) *retryTransport {
+	t := newRetryTransport(http.DefaultTransport, maxRetries, 30*time.Second)
+	t.sleep = func(req *http.Request, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return t
+}
+
+func TestRetryTransport(t *testing.T) {
+	cases := map[string]struct {
+		method   string
+		statuses []int
+		expected int
+		attempts int
+	}{
+		"get succeeds after rate limiting": {
+			method:   http.MethodGet,
+			statuses: []int{429, 502, 200},
+			expected: 200,
+			attempts: 3,
+		},
+		"patch retried on bad gateway": {
+			method:   http.MethodPatch,
+			statuses: []int{503, 200},
+			expected: 200,
+			attempts: 2,
+		},
+		"post retried on rate limiting": {
+			method:   http.MethodPost,
+			statuses: []int{429, 201},
+			expected: 201,
+			attempts: 2,
+		},
+		"post not retried on bad gateway": {
+			method:   http.MethodPost,
+			statuses: []int{502, 201},
+			expected: 502,
+			attempts: 1,
+		},
+		"client error not retried": {
+			method:   http.MethodGet,
+			statuses: []int{400, 200},
+			expected: 400,
+			attempts: 1,
+		},
+		"gives up after max retries": {
+			method:   http.MethodDelete,
+			statuses: []int{504, 504, 504, 504, 204},
+			expected: 504,
+			attempts: 4,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				if r.Method != http.MethodGet && r.Method != http.MethodDelete && string(body) != `{"key":"value"}` {
+					t.Errorf("unexpected body on attempt %v: %q", attempts+1, body)
+				}
+				w.WriteHeader(tc.statuses[attempts])
+				attempts++
+			}))
+			defer server.Close()
+
+			var waits []time.Duration
+			client := &http.Client{Transport: newTestRetryTransport(3, &waits)}
+
+			req, err := http.NewRequest(tc.method, server.URL, bytes.NewBufferString(`{"key":"value"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.expected {
+				t.Fatalf("expected status %v, got %v", tc.expected, resp.StatusCode)
+			}
+			if attempts != tc.attempts {
+				t.Fatalf("expected %v attempts, got %v", tc.attempts, attempts)
+			}
+			if len(waits) != tc.attempts-1 {
+				t.Fatalf("expected %v waits, got %v", tc.attempts-1, waits)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_backoff(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, 10, 30*time.Second)
+
+	for attempt := 0; attempt < 8; attempt++ {
+		max := time.Second << uint(attempt)
+		if max > 30*time.Second {
+			max = 30 * time.Second
+		}
+
+		wait := transport.backoff(attempt, nil)
+		if wait < max/2 || wait > max {
+			t.Fatalf("expected wait of attempt %v between %v and %v, got %v", attempt, max/2, max, wait)
+		}
+	}
+
+	resetAt := time.Now().Add(10 * time.Second).Unix()
+	headers := map[string]http.Header{
+		"retry after seconds": {"Retry-After": []string{"7"}},
+		"retry after date":    {"Retry-After": []string{time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)}},
+		"rate limit reset":    {"X-Rate-Limit-Remaining": []string{"0"}, "X-Rate-Limit-Reset": []string{strconv.FormatInt(resetAt, 10)}},
+		"capped by max wait":  {"Retry-After": []string{"3600"}},
+	}
+	expected := map[string]time.Duration{
+		"retry after seconds": 7 * time.Second,
+		"retry after date":    10 * time.Second,
+		"rate limit reset":    10 * time.Second,
+		"capped by max wait":  30 * time.Second,
+	}
+
+	for name, header := range headers {
+		wait := transport.backoff(0, &http.Response{Header: header})
+		if wait > expected[name] || wait < expected[name]-2*time.Second {
+			t.Fatalf("%v: expected wait of about %v, got %v", name, expected[name], wait)
+		}
+	}
+}
+
+func TestRetryTransport_connectionRefused(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		var waits []time.Duration
+		client := &http.Client{Transport: newTestRetryTransport(2, &waits)}
+
+		req, err := http.NewRequest(method, url, bytes.NewBufferString("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.Do(req); err == nil {
+			t.Fatalf("%v: expected an error from a closed server", method)
+		}
+		if len(waits) != 2 {
+			t.Fatalf("%v: expected a request that never reached the server to be retried, got %v waits", method, len(waits))
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// variableSchema returns the attributes shared by the variable resources of every scope,
+// merged with the scope specific attributes
+func variableSchema(scope map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"key": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Variable name",
+		},
+		"value": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Variable value",
+			Sensitive:   true,
+		},
+		"value_hash": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Hash of the encrypted variable value",
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "VAR",
+			ValidateFunc: validation.StringInSlice([]string{"VAR", "FILE", "SSH_KEY"}, false),
+			Description:  "Variable type. Valid values are `VAR`, `FILE` and `SSH_KEY`",
+		},
+		"file_place": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"CONTAINER", "NONE"}, false),
+			Description:  "Where the file of a `FILE` or `SSH_KEY` variable is placed. Valid values are `CONTAINER` and `NONE`",
+		},
+		"file_path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Path of the file in the container. Required when `file_place` is `CONTAINER`",
+		},
+		"file_chmod": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-7]{3,4}$`), "must be an octal file mode, e.g. 600"),
+			Description:  "Permission of the file in the container, e.g. `600`",
+		},
+		"passphrase": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Passphrase of the private key of an `SSH_KEY` variable",
+		},
+		"public_key": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Public key of an `SSH_KEY` variable",
+		},
+		"key_fingerprint": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Fingerprint of the key of an `SSH_KEY` variable",
+		},
+		"checksum": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Checksum of the value of a `FILE` or `SSH_KEY` variable",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Variable description",
+		},
+		"settable": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Flag to decide whether the variable is settable by pipeline run",
+		},
+		"encrypted": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Flag to decide whether variable encrypted",
+		},
+		"ssh_key": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Flag to decide whether the variable is an SSH key",
+		},
+	}
+
+	for k, v := range scope {
+		s[k] = v
+	}
+
+	return s
+}
+
+// variableCustomizeDiff rejects attribute combinations that don't match the variable type,
+// so they are reported at plan time instead of by the Buddy API
+func variableCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+
+	varType := d.Get("type").(string)
+	filePlace := d.Get("file_place").(string)
+	filePath := d.Get("file_path").(string)
+
+	if varType == "VAR" {
+		for _, key := range []string{"file_place", "file_path", "passphrase"} {
+			if v, ok := d.GetOk(key); ok && v.(string) != "" {
+				return fmt.Errorf("%v can only be set when type is FILE or SSH_KEY", key)
+			}
+		}
+		return nil
+	}
+
+	if _, ok := d.GetOk("passphrase"); ok && varType != "SSH_KEY" {
+		return fmt.Errorf("passphrase can only be set when type is SSH_KEY")
+	}
+
+	if varType == "SSH_KEY" && d.NewValueKnown("encrypted") && !d.Get("encrypted").(bool) {
+		return fmt.Errorf("SSH_KEY variable is always encrypted by Buddy, encrypted must be true")
+	}
+
+	if !d.NewValueKnown("file_place") || !d.NewValueKnown("file_path") {
+		return nil
+	}
+
+	switch filePlace {
+	case "":
+		return fmt.Errorf("file_place is required when type is %v", varType)
+	case "CONTAINER":
+		if filePath == "" {
+			return fmt.Errorf("file_path is required when file_place is CONTAINER")
+		}
+	case "NONE":
+		if filePath != "" {
+			return fmt.Errorf("file_path can only be set when file_place is CONTAINER")
+		}
+	}
+
+	return nil
+}
+
+func expandVariableFile(d *schema.ResourceData) buddyRequestVariableFile {
+	return buddyRequestVariableFile{
+		FilePlace:  d.Get("file_place").(string),
+		FilePath:   d.Get("file_path").(string),
+		FileChmod:  d.Get("file_chmod").(string),
+		Passphrase: d.Get("passphrase").(string),
+	}
+}
+
+// setVariable sets the attributes shared by the variables of every scope from the Buddy response
+func setVariable(d *schema.ResourceData, v buddyResponseVariable) diag.Diagnostics {
+	values := map[string]interface{}{
+		"key":         v.Key,
+		"ssh_key":     v.SSHKey,
+		"settable":    v.Settable,
+		"encrypted":   v.Encrypted,
+		"description": v.Description,
+		"value_hash":  v.Value,
+	}
+
+	// Buddy only returns a hash of encrypted values, keep the configured value instead
+	if !v.Encrypted {
+		values["value"] = v.Value
+	}
+
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return setVariableFile(d, v.buddyResponseVariableFile)
+}
+
+// setVariableFile sets the attributes of FILE and SSH_KEY variables from the Buddy response
+func setVariableFile(d *schema.ResourceData, file buddyResponseVariableFile) diag.Diagnostics {
+	values := map[string]interface{}{
+		"file_place":      file.FilePlace,
+		"file_path":       file.FilePath,
+		"file_chmod":      file.FileChmod,
+		"public_key":      file.PublicValue,
+		"key_fingerprint": file.KeyFingerprint,
+		"checksum":        file.Checksum,
+	}
+
+	if file.Type != "" {
+		values["type"] = file.Type
+	}
+
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
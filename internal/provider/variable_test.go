@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestVariableCustomizeDiff(t *testing.T) {
+	cases := map[string]struct {
+		config map[string]interface{}
+		err    *regexp.Regexp
+	}{
+		"var": {
+			config: map[string]interface{}{},
+		},
+		"var with file place": {
+			config: map[string]interface{}{"file_place": "CONTAINER"},
+			err:    regexp.MustCompile("file_place can only be set when type is FILE or SSH_KEY"),
+		},
+		"file in container": {
+			config: map[string]interface{}{"type": "FILE", "file_place": "CONTAINER", "file_path": "~/.npmrc", "file_chmod": "644"},
+		},
+		"file without place": {
+			config: map[string]interface{}{"type": "FILE"},
+			err:    regexp.MustCompile("file_place is required when type is FILE"),
+		},
+		"file in container without path": {
+			config: map[string]interface{}{"type": "FILE", "file_place": "CONTAINER"},
+			err:    regexp.MustCompile("file_path is required when file_place is CONTAINER"),
+		},
+		"file not placed with path": {
+			config: map[string]interface{}{"type": "FILE", "file_place": "NONE", "file_path": "~/.npmrc"},
+			err:    regexp.MustCompile("file_path can only be set when file_place is CONTAINER"),
+		},
+		"file with passphrase": {
+			config: map[string]interface{}{"type": "FILE", "file_place": "NONE", "passphrase": "secret"},
+			err:    regexp.MustCompile("passphrase can only be set when type is SSH_KEY"),
+		},
+		"ssh key": {
+			config: map[string]interface{}{"type": "SSH_KEY", "encrypted": true, "file_place": "CONTAINER", "file_path": "~/.ssh/id_deploy", "passphrase": "secret"},
+		},
+		"ssh key not encrypted": {
+			config: map[string]interface{}{"type": "SSH_KEY", "file_place": "NONE"},
+			err:    regexp.MustCompile("encrypted must be true"),
+		},
+	}
+
+	r := resourceWorkspaceVariable()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"key":   "DEPLOY_KEY",
+				"value": "dummy",
+			}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if tc.err == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.err != nil && (err == nil || !tc.err.MatchString(err.Error())) {
+				t.Fatalf("expected error matching %q, got %v", tc.err, err)
+			}
+		})
+	}
+}